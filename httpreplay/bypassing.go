@@ -1,8 +1,8 @@
 // Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
 // Licensed under the Mozilla Public License v2.0
 
-//go:build !record && !replay
-// +build !record,!replay
+//go:build !record && !replay && !new_episodes
+// +build !record,!replay,!new_episodes
 
 package httpreplay
 
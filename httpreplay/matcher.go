@@ -0,0 +1,146 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package httpreplay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Names of the matchers that can be set in the TF_HTTPREPLAY_MATCHERS environment variable e.g. "method,path,body,header:opc-retry-token"
+const (
+	matcherMethod       = "method"
+	matcherPath         = "path"
+	matcherQuery        = "query"
+	matcherBody         = "body"
+	matcherHeaderPrefix = "header:"
+)
+
+// replayMatcher matches the requests with the recorded interactions in replay mode, by default by method and path
+var replayMatcher Matcher = matcher
+
+// SetMatchers sets the matchers that a request has to satisfy to replay a recorded interaction
+func SetMatchers(matchers ...Matcher) {
+	replayMatcher = MatcherChain(matchers...)
+}
+
+// scenarioMatcher returns the matcher of the scenarios, from the TF_HTTPREPLAY_MATCHERS environment variable when it is set
+func scenarioMatcher() (Matcher, error) {
+	names, ok := os.LookupEnv("TF_HTTPREPLAY_MATCHERS")
+	if !ok || names == "" {
+		return replayMatcher, nil
+	}
+	matchers, err := ParseMatchers(names)
+	if err != nil {
+		return nil, err
+	}
+	return MatcherChain(matchers...), nil
+}
+
+// MatcherChain returns a Matcher matching the requests that match all the matchers
+func MatcherChain(matchers ...Matcher) Matcher {
+	return func(n int, r *Request, i *Request) bool {
+		for _, m := range matchers {
+			if !m(n, r, i) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ParseMatchers returns the matchers from a comma-separated list of their names: method, path, query, body and header:<name>
+func ParseMatchers(names string) ([]Matcher, error) {
+	var matchers []Matcher
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == matcherMethod:
+			matchers = append(matchers, MatchMethod)
+		case name == matcherPath:
+			matchers = append(matchers, MatchPath)
+		case name == matcherQuery:
+			matchers = append(matchers, MatchQuery)
+		case name == matcherBody:
+			matchers = append(matchers, MatchBody)
+		case strings.HasPrefix(name, matcherHeaderPrefix) && len(name) > len(matcherHeaderPrefix):
+			matchers = append(matchers, MatchHeaders(strings.TrimPrefix(name, matcherHeaderPrefix)))
+		default:
+			return nil, fmt.Errorf("unknown matcher %q, supported matchers: %s, %s, %s, %s, %s<name>", name, matcherMethod, matcherPath, matcherQuery, matcherBody, matcherHeaderPrefix)
+		}
+	}
+	return matchers, nil
+}
+
+// MatchMethod matches the requests with the same method
+func MatchMethod(n int, r *Request, i *Request) bool {
+	return r.Method == i.Method
+}
+
+// MatchPath matches the requests with the same URL without the query string
+func MatchPath(n int, r *Request, i *Request) bool {
+	return stripQuery(r.URL) == stripQuery(i.URL)
+}
+
+// MatchQuery matches the requests with the same query parameters, in any order
+func MatchQuery(n int, r *Request, i *Request) bool {
+	rQuery, err := parseQuery(r.URL)
+	if err != nil {
+		return false
+	}
+	iQuery, err := parseQuery(i.URL)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(rQuery, iQuery)
+}
+
+func parseQuery(rawURL string) (url.Values, error) {
+	requestURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return url.ParseQuery(requestURL.RawQuery)
+}
+
+// MatchBody matches the requests with the same body, JSON bodies are compared regardless of the order of the keys and of white space
+func MatchBody(n int, r *Request, i *Request) bool {
+	return canonicalBody(r.Body) == canonicalBody(i.Body)
+}
+
+// canonicalBody returns the JSON body with sorted keys and no white space, other bodies are returned as is
+func canonicalBody(body string) string {
+	var parsed interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&parsed); err != nil || decoder.More() {
+		return body
+	}
+
+	canonical := &bytes.Buffer{}
+	encoder := json.NewEncoder(canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(parsed); err != nil {
+		return body
+	}
+	return strings.TrimSuffix(canonical.String(), "\n")
+}
+
+// MatchHeaders returns a Matcher matching the requests with the same values of the headers.
+// Redacted headers such as Authorization are not recorded and should not be matched.
+func MatchHeaders(names ...string) Matcher {
+	return func(n int, r *Request, i *Request) bool {
+		for _, name := range names {
+			if !reflect.DeepEqual(r.Headers.Values(name), i.Headers.Values(name)) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+// Run with a command something like:
+//   go test -run TestMatcher
+
+package httpreplay
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMatchers(t *testing.T) {
+	recorded := &Request{
+		Method:  "POST",
+		URL:     "https://iaas.us-ashburn-1.oraclecloud.com/20160918/vcns?limit=10&compartmentId=ocid1",
+		Body:    `{"displayName": "vcn", "cidrBlocks": ["10.0.0.0/16"], "freeformTags": {"b": "2", "a": "1"}}`,
+		Headers: http.Header{"Opc-Retry-Token": []string{"token"}},
+	}
+
+	tests := []struct {
+		desc     string
+		matcher  Matcher
+		request  Request
+		expected bool
+	}{
+		{"same method", MatchMethod, Request{Method: "POST"}, true},
+		{"other method", MatchMethod, Request{Method: "GET"}, false},
+		{"same path", MatchPath, Request{URL: "https://iaas.us-ashburn-1.oraclecloud.com/20160918/vcns"}, true},
+		{"other path", MatchPath, Request{URL: "https://iaas.us-ashburn-1.oraclecloud.com/20160918/subnets"}, false},
+		{"same query in another order", MatchQuery, Request{URL: "https://iaas.us-ashburn-1.oraclecloud.com/20160918/vcns?compartmentId=ocid1&limit=10"}, true},
+		{"other query", MatchQuery, Request{URL: "https://iaas.us-ashburn-1.oraclecloud.com/20160918/vcns?compartmentId=ocid2&limit=10"}, false},
+		{"same JSON body in another order", MatchBody, Request{Body: `{"freeformTags":{"a":"1","b":"2"},"cidrBlocks":["10.0.0.0/16"],"displayName":"vcn"}`}, true},
+		{"other JSON body", MatchBody, Request{Body: `{"displayName":"vcn2","cidrBlocks":["10.0.0.0/16"],"freeformTags":{"a":"1","b":"2"}}`}, false},
+		{"same header", MatchHeaders("opc-retry-token"), Request{Headers: http.Header{"Opc-Retry-Token": []string{"token"}}}, true},
+		{"missing header", MatchHeaders("opc-retry-token"), Request{}, false},
+		{"chain", MatcherChain(MatchMethod, MatchPath), Request{Method: "POST", URL: "https://iaas.us-ashburn-1.oraclecloud.com/20160918/vcns"}, true},
+		{"chain with a failed matcher", MatcherChain(MatchMethod, MatchPath), Request{Method: "GET", URL: "https://iaas.us-ashburn-1.oraclecloud.com/20160918/vcns"}, false},
+	}
+	for _, test := range tests {
+		if result := test.matcher(0, &test.request, recorded); result != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.desc, test.expected, result)
+		}
+	}
+
+	if body := "not json"; !MatchBody(0, &Request{Body: body}, &Request{Body: body}) {
+		t.Errorf("expected the same non JSON bodies to match")
+	}
+}
+
+func TestParseMatchers(t *testing.T) {
+	matchers, err := ParseMatchers("method, path,query,body,header:opc-retry-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matchers) != 5 {
+		t.Errorf("expected 5 matchers, got %d", len(matchers))
+	}
+
+	for _, names := range []string{"method,url", "header:", ""} {
+		if _, err := ParseMatchers(names); err == nil {
+			t.Errorf("expected error for matchers %q", names)
+		}
+	}
+}
+
+func TestGetInteractionWithBodyMatcher(t *testing.T) {
+	s := NewScenario("TestGetInteractionWithBodyMatcher")
+	s.Matcher = MatcherChain(MatchMethod, MatchPath, MatchBody)
+	for _, name := range []string{"first", "second"} {
+		s.AddInteraction(&Interaction{
+			Request:  Request{Method: "POST", URL: "https://objectstorage/n/namespace/b/", Body: `{"name":"` + name + `"}`},
+			Response: Response{Body: name, Code: 200},
+		})
+	}
+
+	for _, name := range []string{"second", "first"} {
+		body := `{ "name": "` + name + `" }`
+		bodyParsed, _ := unmarshal([]byte(body))
+		i, err := s.GetInteraction(Request{Method: "POST", URL: "https://objectstorage/n/namespace/b/", Body: body, BodyParsed: bodyParsed})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if i.Response.Body != name {
+			t.Errorf("expected the interaction of %s, got %s", name, i.Response.Body)
+		}
+	}
+
+	if _, err := s.GetInteraction(Request{Method: "POST", URL: "https://objectstorage/n/namespace/b/", Body: `{"name":"third"}`}); err != ErrInteractionNotFound {
+		t.Errorf("expected %v, got %v", ErrInteractionNotFound, err)
+	}
+}
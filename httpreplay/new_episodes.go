@@ -0,0 +1,50 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+//go:build new_episodes
+// +build new_episodes
+
+package httpreplay
+
+import "net/http"
+
+var recorder *Recorder
+
+// SetScenario loads the recorded scenario, or creates a new one, to replay its interactions and record the new ones
+func SetScenario(name string) error {
+	var err error
+	if recorder, err = NewRecorderAsMode(name, ModeNewEpisodes); err != nil {
+		debugLogf("Making a new recorder '%s' failed, %v", name, err)
+		return err
+	}
+	requestMatcher, err := scenarioMatcher()
+	if err != nil {
+		return err
+	}
+	recorder.SetMatcher(requestMatcher)
+	recorder.SetTransformer(recorder.scenario.transformer)
+	return nil
+}
+
+// SaveScenario reports the interactions that were not replayed and saves the scenario with the new interactions
+func SaveScenario() error {
+	debugLogf("Saving the recorder")
+	err := recorder.Stop()
+	recorder = nil
+	return err
+}
+
+// InstallRecorder puts the recording transport into the http client, then returns a type that is compatible with the SDK's HTTPRequestDispatcher
+func InstallRecorder(client *http.Client) (HTTPRecordingClient, error) {
+	return InstallRecorderForRecodReplay(client, recorder)
+}
+
+// ShouldRetryImmediately returns false since the new interactions are sent to the services
+func ShouldRetryImmediately() bool {
+	return false
+}
+
+// ModeRecordReplay returns true in record and replay
+func ModeRecordReplay() bool {
+	return true
+}
@@ -25,6 +25,8 @@ const (
 	ModeRecording Mode = iota
 	ModeReplaying
 	ModeDisabled
+	// ModeNewEpisodes replays the recorded interactions and records the requests that have no recorded interaction
+	ModeNewEpisodes
 )
 
 // Transformer converts a request and a saved interaction into a result.  The Interaction is passed by value to suggest that it should not be modified.
@@ -68,6 +70,9 @@ type Recorder struct {
 	// transformer is used to adjust responses to match changes in requests
 	transformer Transformer
 
+	// redactor removes the secrets from the recorded interactions before they are saved
+	redactor Redactor
+
 	// count is for debug logging -- how many requests have been matched
 	count int
 }
@@ -92,6 +97,11 @@ func (r *Recorder) SetTransformer(t Transformer) {
 	r.transformer = t
 }
 
+// SetRedactor can be used to override the default redactor of the headers and body fields holding secrets
+func (r *Recorder) SetRedactor(redactor Redactor) {
+	r.redactor = redactor
+}
+
 var mut sync.RWMutex
 
 func (r *Recorder) invokeTransformer(req *http.Request) (*Interaction, *Response, error) {
@@ -237,9 +247,42 @@ func (r *Recorder) requestHandler(req *http.Request, realTransport http.RoundTri
 	if r.mode == ModeReplaying {
 		return r.invokeTransformer(req)
 	}
+	if r.mode == ModeNewEpisodes {
+		return r.replayOrRecordInteraction(req, realTransport)
+	}
 	return r.recordInteraction(req, realTransport)
 }
 
+// replayOrRecordInteraction replays the recorded interaction of the request, or sends the request and records it when there is none
+func (r *Recorder) replayOrRecordInteraction(req *http.Request, realTransport http.RoundTripper) (*Interaction, *Response, error) {
+	// Keep the body to send it again when the request is recorded
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+			debugLogf("-=-=-=- Error reading the request body: %v", err)
+			return nil, nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+		req.ContentLength = int64(len(reqBody))
+	}
+
+	interaction, response, err := r.invokeTransformer(req)
+	if err != ErrInteractionNotFound {
+		return interaction, response, err
+	}
+
+	debugLogf("\t-> Recording new interaction for %s %s", req.Method, req.URL.String())
+	if req.Body != nil {
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+	if interaction, response, err = r.recordInteraction(req, realTransport); err != nil {
+		return nil, nil, err
+	}
+	r.scenario.markUsed(interaction.Index)
+	return interaction, response, nil
+}
+
 func InstallRecorderForRecodReplay(client *http.Client, recorder *Recorder) (HTTPRecordingClient, error) {
 	err := recorder.HookTransport(client)
 	if err != nil {
@@ -301,12 +344,17 @@ func NewRecorderAsMode(scenarioName string, mode Mode) (*Recorder, error) {
 			// Create new scenario and enter in recording mode
 			s = NewScenario(scenarioName)
 		} else {
-			// Load scenario from file and enter replay mode
+			// Load scenario from file and enter replay mode, or new episodes mode
 			s, err = Load(scenarioName)
+			if mode == ModeNewEpisodes && os.IsNotExist(err) {
+				s, err = NewScenario(scenarioName), nil
+			}
 			if err != nil {
 				return nil, err
 			}
-			mode = ModeReplaying
+			if mode != ModeNewEpisodes {
+				mode = ModeReplaying
+			}
 		}
 	}
 
@@ -314,14 +362,21 @@ func NewRecorderAsMode(scenarioName string, mode Mode) (*Recorder, error) {
 		mode:        mode,
 		scenario:    s,
 		transformer: defaultTransformer,
+		redactor:    defaultRedactor,
 	}
 
 	return r, nil
 }
 
-// Stop is used to stop the recorder and save any recorded interactions
+// Stop is used to stop the recorder, report the interactions that were not replayed and save any recorded interactions
 func (r *Recorder) Stop() error {
-	if r.mode == ModeRecording {
+	if r.mode == ModeReplaying || r.mode == ModeNewEpisodes {
+		if err := r.scenario.ReportUnusedInteractions(); err != nil {
+			debugLogf("Error reporting the unused interactions: %v", err)
+		}
+	}
+	if r.mode == ModeRecording || r.mode == ModeNewEpisodes {
+		r.scenario.Redact(r.redactor)
 		if err := r.scenario.Save(); err != nil {
 			return err
 		}
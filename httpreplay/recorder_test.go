@@ -6,7 +6,14 @@
 
 package httpreplay
 
-import "testing"
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestUnmarshal(t *testing.T) {
 	t.Run("Unmarshal Array", func(t *testing.T) {
@@ -64,3 +71,83 @@ func TestUnmarshal(t *testing.T) {
 		}
 	})
 }
+
+func TestNewEpisodes(t *testing.T) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(workingDir)
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		requests = append(requests, req.URL.Path)
+		w.Write([]byte("sent " + string(body)))
+	}))
+	defer server.Close()
+
+	scenario := NewScenario("TestNewEpisodes")
+	for _, path := range []string{"/recorded", "/unused"} {
+		scenario.AddInteraction(&Interaction{
+			Request:  Request{Method: "GET", URL: server.URL + path},
+			Response: Response{Body: "recorded " + path, Code: 200, Status: "200 OK"},
+		})
+	}
+	if err := scenario.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder, err := NewRecorderAsMode("TestNewEpisodes", ModeNewEpisodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.SetMatcher(matcher)
+	client := &http.Client{Transport: http.DefaultTransport}
+	if err := recorder.HookTransport(client); err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(method string, path string, body string) string {
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer res.Body.Close()
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return string(resBody)
+	}
+	if body := send("GET", "/recorded", ""); body != "recorded /recorded" {
+		t.Errorf("expected the recorded response, got %s", body)
+	}
+	if body := send("POST", "/new", `{"name":"new"}`); body != `sent {"name":"new"}` {
+		t.Errorf("expected the response of the server, got %s", body)
+	}
+	if len(requests) != 1 || requests[0] != "/new" {
+		t.Errorf("expected only the new request to be sent, got %v", requests)
+	}
+
+	unused := recorder.scenario.UnusedInteractions()
+	if len(unused) != 1 || unused[0].Request.URL != server.URL+"/unused" {
+		t.Errorf("expected the /unused interaction to be reported, got %v", unused)
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := Load("TestNewEpisodes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Interactions) != 3 || saved.Interactions[2].Request.Body != `{"name":"new"}` || saved.Interactions[2].Response.Body != `sent {"name":"new"}` {
+		t.Errorf("expected the new interaction to be appended to the scenario, got %v", saved.Interactions)
+	}
+
+	if _, err := NewRecorderAsMode("TestNewEpisodesNotRecorded", ModeNewEpisodes); err != nil {
+		t.Errorf("expected a new scenario when it was not recorded, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package httpreplay
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces the secrets in the recorded interactions
+const redactedValue = "REDACTED"
+
+// Redactor removes the secrets from an interaction before the scenario is saved
+type Redactor func(*Interaction)
+
+// redactedHeaders are the canonical names of the headers that are redacted from the requests and responses
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Opc-Obo-Token": true,
+	"Opc-Principal": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// redactedFieldPattern matches the names of the JSON body and form fields that are redacted e.g. adminPassword or privateKey, but not secretId
+var redactedFieldPattern = regexp.MustCompile(`(?i)(password|passphrase|secret|private_?key)$`)
+
+// RedactHeaders adds headers to redact from the recorded interactions
+func RedactHeaders(names ...string) {
+	for _, name := range names {
+		redactedHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+}
+
+// defaultRedactor redacts the headers, the JSON body fields and the form values holding secrets
+func defaultRedactor(i *Interaction) {
+	i.Request.Headers = redactHeaders(i.Request.Headers)
+	i.Request.Form = redactForm(i.Request.Form)
+	i.Request.Body = redactBody(i.Request.Body)
+	i.Request.BodyParsed = nil
+	i.Response.Headers = redactHeaders(i.Response.Headers)
+	i.Response.Body = redactBody(i.Response.Body)
+	i.Response.BodyParsed = nil
+}
+
+func redactHeaders(headers http.Header) http.Header {
+	if headers == nil {
+		return nil
+	}
+	redacted := headers.Clone()
+	for name, values := range redacted {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			redacted[name] = make([]string, len(values))
+			for index := range values {
+				redacted[name][index] = redactedValue
+			}
+		}
+	}
+	return redacted
+}
+
+func redactForm(form url.Values) url.Values {
+	if form == nil {
+		return nil
+	}
+	redacted := url.Values{}
+	for name, values := range form {
+		redacted[name] = values
+		if redactedFieldPattern.MatchString(name) {
+			redacted[name] = []string{redactedValue}
+		}
+	}
+	return redacted
+}
+
+// redactBody returns the body with the values of the secret fields redacted, the body is returned as is when it is not JSON or has no secret
+func redactBody(body string) string {
+	var parsed interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&parsed); err != nil || decoder.More() {
+		return body
+	}
+	if !redactJSONValue(parsed) {
+		return body
+	}
+
+	redacted := &bytes.Buffer{}
+	encoder := json.NewEncoder(redacted)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(parsed); err != nil {
+		debugLogf("Error redacting body: %v", err)
+		return redactedValue
+	}
+	return strings.TrimSuffix(redacted.String(), "\n")
+}
+
+// redactJSONValue redacts the secret fields of the objects in place and returns whether a field was redacted
+func redactJSONValue(value interface{}) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range v {
+			if fieldValue != nil && redactedFieldPattern.MatchString(key) {
+				if _, isObject := fieldValue.(map[string]interface{}); !isObject {
+					v[key] = redactedValue
+					redacted = true
+					continue
+				}
+			}
+			redacted = redactJSONValue(fieldValue) || redacted
+		}
+	case []interface{}:
+		for _, item := range v {
+			redacted = redactJSONValue(item) || redacted
+		}
+	}
+	return redacted
+}
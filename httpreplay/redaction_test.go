@@ -0,0 +1,78 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+// Run with a command something like:
+//   go test -run TestRedact
+
+package httpreplay
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		desc     string
+		body     string
+		expected string
+	}{
+		{"no secret", `{"displayName": "adb", "secretId": "ocid1.vaultsecret"}`, `{"displayName": "adb", "secretId": "ocid1.vaultsecret"}`},
+		{"secrets", `{"adminPassword":"Welcome1#","displayName":"adb","sshKeys":{"privateKey":"-----BEGIN"}}`, `{"adminPassword":"REDACTED","displayName":"adb","sshKeys":{"privateKey":"REDACTED"}}`},
+		{"secrets in an array", `[{"password":"p","id":1},{"id":2}]`, `[{"id":1,"password":"REDACTED"},{"id":2}]`},
+		{"null secret", `{"password":null}`, `{"password":null}`},
+		{"not json", `password=secret`, `password=secret`},
+	}
+	for _, test := range tests {
+		if result := redactBody(test.body); result != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.desc, test.expected, result)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	s := NewScenario("TestRedact")
+	requestHeaders := http.Header{"Authorization": []string{"Signature"}, "Opc-Obo-Token": []string{"token"}, "Content-Type": []string{"application/json"}}
+	s.AddInteraction(&Interaction{
+		Request: Request{
+			Method:  "POST",
+			URL:     "https://database/20160918/autonomousDatabases/ocid1/actions/generateWallet",
+			Body:    `{"password":"Welcome1#"}`,
+			Form:    url.Values{"walletPassword": []string{"Welcome1#"}, "name": []string{"adb"}},
+			Headers: requestHeaders,
+		},
+		Response: Response{
+			Body:    "wallet",
+			Headers: http.Header{"Set-Cookie": []string{"session"}, "Opc-Request-Id": []string{"id"}},
+		},
+	})
+
+	RedactHeaders("opc-request-id")
+	defer delete(redactedHeaders, "Opc-Request-Id")
+	s.Redact(defaultRedactor)
+
+	i := s.Interactions[0]
+	if expected := (http.Header{"Authorization": []string{"REDACTED"}, "Opc-Obo-Token": []string{"REDACTED"}, "Content-Type": []string{"application/json"}}); !reflect.DeepEqual(expected, i.Request.Headers) {
+		t.Errorf("expected request headers %v, got %v", expected, i.Request.Headers)
+	}
+	if requestHeaders.Get("Authorization") != "Signature" {
+		t.Errorf("expected the headers of the request to be left unchanged")
+	}
+	if expected := `{"password":"REDACTED"}`; i.Request.Body != expected {
+		t.Errorf("expected request body %s, got %s", expected, i.Request.Body)
+	}
+	if expected := (url.Values{"walletPassword": []string{"REDACTED"}, "name": []string{"adb"}}); !reflect.DeepEqual(expected, i.Request.Form) {
+		t.Errorf("expected request form %v, got %v", expected, i.Request.Form)
+	}
+	if expected := (http.Header{"Set-Cookie": []string{"REDACTED"}, "Opc-Request-Id": []string{"REDACTED"}}); !reflect.DeepEqual(expected, i.Response.Headers) {
+		t.Errorf("expected response headers %v, got %v", expected, i.Response.Headers)
+	}
+	if i.Response.Body != "wallet" {
+		t.Errorf("expected the response body to be left unchanged, got %s", i.Response.Body)
+	}
+	if !reflect.DeepEqual(s.Interactions, s.sortedInteractions) {
+		t.Errorf("expected the sorted interactions to be redacted")
+	}
+}
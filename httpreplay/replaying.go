@@ -22,16 +22,24 @@ func SetScenario(name string) error {
 		// cleanup existing files in /tmp folder
 		RemoveContents("/tmp")
 		// cleanup existing
-		recorder.SetMatcher(matcher)
+		requestMatcher, err := scenarioMatcher()
+		if err != nil {
+			return err
+		}
+		recorder.SetMatcher(requestMatcher)
 		recorder.SetTransformer(recorder.scenario.transformer)
 	}
 	return err
 }
 
-// SaveScenario does nothing when recording
+// SaveScenario reports the recorded interactions that were not replayed
 func SaveScenario() error {
+	var err error
+	if recorder != nil {
+		err = recorder.Stop()
+	}
 	recorder = nil
-	return nil
+	return err
 }
 
 // InstallRecorder puts the recording transport into the http client, then returns a type that is compatible with the SDK's HTTPRequestDispatcher
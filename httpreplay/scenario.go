@@ -412,6 +412,47 @@ func (s *Scenario) GetInteractionWithBodyFromList(r Request, list []*Interaction
 	return iMax, nil
 }
 
+// markUsed counts a use of the interaction, e.g. of an interaction recorded in new episodes mode
+func (s *Scenario) markUsed(n int) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	s.updateUsageCount(n)
+}
+
+// UnusedInteractions returns the interactions that were never replayed, in the order they were recorded
+func (s *Scenario) UnusedInteractions() Interactions {
+	s.Mu.RLock()
+	defer s.Mu.RUnlock()
+
+	interactions := make(Interactions, len(s.Interactions))
+	copy(interactions, s.Interactions)
+	sort.Stable(byUsage(interactions))
+
+	unused := Interactions{}
+	for _, i := range interactions {
+		if i.Uses > 0 {
+			break
+		}
+		unused = append(unused, i)
+	}
+	return unused
+}
+
+// ReportUnusedInteractions logs the interactions that were never replayed, and writes them to <scenario>-unused.yaml
+// under the directory set in the TF_HTTPREPLAY_UNUSED_REPORT_DIR environment variable
+func (s *Scenario) ReportUnusedInteractions() error {
+	unused := s.UnusedInteractions()
+	for _, i := range unused {
+		debugLogf("[WARN] Interaction %d of scenario %s was never used: %s %s", i.Index, s.Name, i.Request.Method, i.Request.URL)
+	}
+
+	reportDir, ok := os.LookupEnv("TF_HTTPREPLAY_UNUSED_REPORT_DIR")
+	if !ok || reportDir == "" || len(unused) == 0 {
+		return nil
+	}
+	return save(unused, filepath.Join(reportDir, fmt.Sprintf("%s-unused.yaml", s.Name)))
+}
+
 // Reset returns us to the beginning of the scenario
 func (s *Scenario) Reset() {
 	for index := range s.Interactions {
@@ -422,6 +463,19 @@ func (s *Scenario) Reset() {
 	sort.Stable(byIndex(s.sortedInteractions))
 }
 
+// Redact removes the secrets from the interactions, it is called before the scenario is saved
+func (s *Scenario) Redact(redactor Redactor) {
+	if redactor == nil {
+		return
+	}
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	for index := range s.Interactions {
+		redactor(&s.Interactions[index])
+	}
+	copy(s.sortedInteractions, s.Interactions)
+}
+
 // Save writes the scenario data on disk for future re-use
 func (s *Scenario) Save() error {
 	s.Mu.RLock()
@@ -0,0 +1,297 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package acctest
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/oracle/oci-go-sdk/v65/common"
+
+	tf_resource "github.com/oracle/terraform-provider-oci/internal/tfresource"
+	utils "github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+const (
+	// TestRunTagKey is the freeform tag set by the tests on their resources, the tag sweepers delete the resources
+	// with this tag that were leaked by crashed test runs
+	TestRunTagKey = "TerraformTestRun"
+
+	// TagSweeperName is the name of the sweeper deleting the tagged resources, run it with -sweep-run=TagAndAge
+	TagSweeperName = "TagAndAge"
+
+	defaultSweepMinAge = 24 * time.Hour
+)
+
+// TestRunId identifies the resources of this test run in their TestRunTagKey tag, it is read from the test_run_id setting
+// or generated
+var TestRunId = getTestRunId()
+
+// SweepableResource is a resource listed by a tag sweeper
+type SweepableResource struct {
+	Id           string
+	Name         string
+	FreeformTags map[string]string
+	TimeCreated  time.Time
+}
+
+// NewSweepableResource returns the SweepableResource of the fields of a resource summary returned by the SDK
+func NewSweepableResource(id *string, name *string, freeformTags map[string]string, timeCreated *common.SDKTime) SweepableResource {
+	r := SweepableResource{FreeformTags: freeformTags}
+	if id != nil {
+		r.Id = *id
+	}
+	if name != nil {
+		r.Name = *name
+	}
+	if timeCreated != nil {
+		r.TimeCreated = timeCreated.Time
+	}
+	return r
+}
+
+/*
+TagSweeper lists and deletes the resources of a type regardless of the process that created them.
+
+	Name is the name of the sweeper of the resource e.g. CoreVcn, as it appears in the values of DependencyGraph
+	ResourceType is the key of the resource in DependencyGraph e.g. vcn
+	List returns the resources of a compartment that are not deleted
+	Delete deletes a resource and waits for the deletion, so that its parents can be deleted next
+*/
+type TagSweeper struct {
+	Name         string
+	ResourceType string
+	List         func(compartmentId string) ([]SweepableResource, error)
+	Delete       func(resourceId string) error
+}
+
+// TagSweepFilter selects the resources to sweep by their freeform tag and their age
+type TagSweepFilter struct {
+	TagKey string
+	// TagValue is the value of the tag, any value matches when it is empty
+	TagValue string
+	MinAge   time.Duration
+	Now      time.Time
+}
+
+// SweepPlanEntry is a resource that the tag sweeper deletes
+type SweepPlanEntry struct {
+	Sweeper  *TagSweeper
+	Resource SweepableResource
+}
+
+var tagSweepers = map[string]*TagSweeper{}
+
+func init() {
+	resource.AddTestSweepers(TagSweeperName, &resource.Sweeper{
+		Name: TagSweeperName,
+		F:    SweepByTagAndAge,
+	})
+}
+
+// AddTagSweeper registers the tag sweeper of a resource type, unless it is in the sweep_exclude_list setting
+func AddTagSweeper(sweeper *TagSweeper) {
+	if InSweeperExcludeList(sweeper.Name) {
+		return
+	}
+	tagSweepers[sweeper.Name] = sweeper
+}
+
+/*
+tagTestRunResources adds the TestRunTagKey tag to the provider default_freeform_tags when the sweep_tag_resources
+setting is true, so that the resources created by the tests can be swept by the tag sweepers if the run crashes.
+It is opt-in as the tests checking the number of freeform_tags of their resources would see the extra tag.
+*/
+func tagTestRunResources() {
+	if tagResources, _ := strconv.ParseBool(utils.GetEnvSettingWithBlankDefault("sweep_tag_resources")); !tagResources {
+		return
+	}
+	tf_resource.DefaultFreeformTags = tf_resource.MergeDefaultTags(map[string]interface{}{TestRunTagKey: TestRunId}, tf_resource.DefaultFreeformTags)
+}
+
+func getTestRunId() string {
+	if testRunId := utils.GetEnvSettingWithBlankDefault("test_run_id"); testRunId != "" {
+		return testRunId
+	}
+	return strconv.FormatInt(time.Now().Unix(), 10)
+}
+
+/*
+GetTagSweepFilter returns the filter of the tag sweeper from the settings:
+
+	sweep_tag: the freeform tag of the resources to sweep, as key or key=value, TerraformTestRun by default
+	sweep_min_age: the minimum age of the resources to sweep e.g. 2h30m, 24h by default, so that the resources of
+	               the test runs in progress are not deleted
+*/
+func GetTagSweepFilter() (*TagSweepFilter, error) {
+	filter := &TagSweepFilter{TagKey: TestRunTagKey, MinAge: defaultSweepMinAge, Now: time.Now()}
+
+	if tag := strings.TrimSpace(utils.GetEnvSettingWithBlankDefault("sweep_tag")); tag != "" {
+		keyValue := strings.SplitN(tag, "=", 2)
+		filter.TagKey = strings.TrimSpace(keyValue[0])
+		if len(keyValue) == 2 {
+			filter.TagValue = strings.TrimSpace(keyValue[1])
+		}
+		if filter.TagKey == "" {
+			return nil, fmt.Errorf("[ERROR] invalid sweep_tag '%s', expected key or key=value", tag)
+		}
+	}
+
+	if minAge := utils.GetEnvSettingWithBlankDefault("sweep_min_age"); minAge != "" {
+		duration, err := time.ParseDuration(minAge)
+		if err != nil || duration < 0 {
+			return nil, fmt.Errorf("[ERROR] invalid sweep_min_age '%s', expected a duration such as 24h", minAge)
+		}
+		filter.MinAge = duration
+	}
+	return filter, nil
+}
+
+// Matches returns whether a resource has the tag of the filter and is older than its minimum age
+func (f *TagSweepFilter) Matches(r SweepableResource) bool {
+	value, ok := r.FreeformTags[f.TagKey]
+	if !ok || (f.TagValue != "" && value != f.TagValue) {
+		return false
+	}
+	return !r.TimeCreated.IsZero() && f.Now.Sub(r.TimeCreated) >= f.MinAge
+}
+
+func (f *TagSweepFilter) String() string {
+	tag := f.TagKey
+	if f.TagValue != "" {
+		tag += "=" + f.TagValue
+	}
+	return fmt.Sprintf("tag %s, older than %s", tag, f.MinAge)
+}
+
+/*
+GetTagSweepOrder returns the registered tag sweepers in the reverse order of DependencyGraph: the sweepers of the
+resources depending on a resource come before its sweeper, e.g. CoreInstance before CoreSubnet before CoreVcn.
+The sweepers that are not related are sorted by name.
+*/
+func GetTagSweepOrder() ([]*TagSweeper, error) {
+	if DependencyGraph == nil {
+		InitDependencyGraph()
+	}
+
+	// parents[child] are the registered sweepers that have to run after the sweeper child
+	parents := map[string][]string{}
+	pendingChildren := map[string]int{}
+	var names []string
+	for name, sweeper := range tagSweepers {
+		names = append(names, name)
+		for _, child := range DependencyGraph[sweeper.ResourceType] {
+			if _, ok := tagSweepers[child]; ok && child != name {
+				parents[child] = append(parents[child], name)
+				pendingChildren[name]++
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var ready []string
+	for _, name := range names {
+		if pendingChildren[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	var order []*TagSweeper
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, tagSweepers[name])
+
+		sort.Strings(parents[name])
+		for _, parent := range parents[name] {
+			if pendingChildren[parent]--; pendingChildren[parent] == 0 {
+				ready = append(ready, parent)
+				sort.Strings(ready)
+			}
+		}
+	}
+
+	if len(order) != len(names) {
+		var cycle []string
+		for _, name := range names {
+			if pendingChildren[name] > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		return nil, fmt.Errorf("[ERROR] the dependencies of the sweepers %s are cyclic", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// GetTagSweepPlan returns the resources of a compartment matching the filter, in the order in which they are deleted
+func GetTagSweepPlan(compartmentId string, filter *TagSweepFilter) ([]SweepPlanEntry, error) {
+	order, err := GetTagSweepOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []SweepPlanEntry
+	for _, sweeper := range order {
+		resources, err := sweeper.List(compartmentId)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] unable to list the resources of the sweeper %s in the compartment %s: %v", sweeper.Name, compartmentId, err)
+		}
+		for _, r := range resources {
+			if filter.Matches(r) {
+				plan = append(plan, SweepPlanEntry{Sweeper: sweeper, Resource: r})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// FormatTagSweepPlan returns the deletion plan with a line per resource
+func FormatTagSweepPlan(plan []SweepPlanEntry, filter *TagSweepFilter) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Sweep plan for the resources with the %s: %d resources\n", filter, len(plan)))
+	for index, entry := range plan {
+		sb.WriteString(fmt.Sprintf("%4d. %s %s (%s, created %s)\n", index+1, entry.Sweeper.Name, entry.Resource.Id, entry.Resource.Name,
+			entry.Resource.TimeCreated.UTC().Format(time.RFC3339)))
+	}
+	return sb.String()
+}
+
+/*
+SweepByTagAndAge deletes the resources of a compartment having the sweep_tag freeform tag and older than sweep_min_age,
+children before parents. When the sweep_dry_run setting is true the deletion plan is printed and nothing is deleted.
+The deletion continues after a failure, and the failures are returned together.
+*/
+func SweepByTagAndAge(compartmentId string) error {
+	filter, err := GetTagSweepFilter()
+	if err != nil {
+		return err
+	}
+	plan, err := GetTagSweepPlan(compartmentId, filter)
+	if err != nil {
+		return err
+	}
+
+	dryRun, _ := strconv.ParseBool(utils.GetEnvSettingWithBlankDefault("sweep_dry_run"))
+	fmt.Print(FormatTagSweepPlan(plan, filter))
+	if dryRun {
+		log.Printf("[INFO] sweep_dry_run is set, no resource is deleted")
+		return nil
+	}
+
+	var failures []string
+	for _, entry := range plan {
+		log.Printf("[DEBUG] Sweeper %s deleting %s", entry.Sweeper.Name, entry.Resource.Id)
+		if err := entry.Sweeper.Delete(entry.Resource.Id); err != nil {
+			failures = append(failures, fmt.Sprintf("%s %s: %v", entry.Sweeper.Name, entry.Resource.Id, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("[ERROR] unable to delete %d of %d resources:\n%s", len(failures), len(plan), strings.Join(failures, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package acctest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tf_resource "github.com/oracle/terraform-provider-oci/internal/tfresource"
+)
+
+// setTagSweepers replaces the registered tag sweepers for the duration of a test
+func setTagSweepers(t *testing.T, sweepers ...*TagSweeper) {
+	registered := tagSweepers
+	tagSweepers = map[string]*TagSweeper{}
+	for _, sweeper := range sweepers {
+		AddTagSweeper(sweeper)
+	}
+	t.Cleanup(func() {
+		tagSweepers = registered
+	})
+}
+
+// testTagSweeper returns a sweeper listing the given resources and recording the deletions
+func testTagSweeper(name string, resourceType string, deleted *[]string, resources ...SweepableResource) *TagSweeper {
+	return &TagSweeper{
+		Name:         name,
+		ResourceType: resourceType,
+		List: func(compartmentId string) ([]SweepableResource, error) {
+			return resources, nil
+		},
+		Delete: func(resourceId string) error {
+			*deleted = append(*deleted, resourceId)
+			if strings.HasPrefix(resourceId, "failing") {
+				return fmt.Errorf("delete failed")
+			}
+			return nil
+		},
+	}
+}
+
+func TestUnitGetTagSweepFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		minAge   string
+		wantKey  string
+		wantVal  string
+		wantAge  time.Duration
+		wantFail bool
+	}{
+		{name: "defaults", wantKey: TestRunTagKey, wantAge: defaultSweepMinAge},
+		{name: "key", tag: "Owner", minAge: "2h30m", wantKey: "Owner", wantAge: 150 * time.Minute},
+		{name: "key and value", tag: " Owner = ci ", minAge: "0s", wantKey: "Owner", wantVal: "ci"},
+		{name: "empty key", tag: "=ci", wantFail: true},
+		{name: "invalid age", minAge: "one day", wantFail: true},
+		{name: "negative age", minAge: "-1h", wantFail: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("TF_VAR_sweep_tag", test.tag)
+			t.Setenv("TF_VAR_sweep_min_age", test.minAge)
+
+			filter, err := GetTagSweepFilter()
+			if test.wantFail {
+				if err == nil {
+					t.Errorf("expected an error, got the filter %v", filter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if filter.TagKey != test.wantKey || filter.TagValue != test.wantVal || filter.MinAge != test.wantAge {
+				t.Errorf("expected the tag %s=%s older than %s, got %v", test.wantKey, test.wantVal, test.wantAge, filter)
+			}
+		})
+	}
+}
+
+func TestUnitTagSweepFilterMatches(t *testing.T) {
+	now := time.Now()
+	filter := &TagSweepFilter{TagKey: TestRunTagKey, TagValue: "1", MinAge: time.Hour, Now: now}
+
+	tests := []struct {
+		name     string
+		resource SweepableResource
+		want     bool
+	}{
+		{"old and tagged", SweepableResource{FreeformTags: map[string]string{TestRunTagKey: "1"}, TimeCreated: now.Add(-2 * time.Hour)}, true},
+		{"recent", SweepableResource{FreeformTags: map[string]string{TestRunTagKey: "1"}, TimeCreated: now.Add(-time.Minute)}, false},
+		{"other test run", SweepableResource{FreeformTags: map[string]string{TestRunTagKey: "2"}, TimeCreated: now.Add(-2 * time.Hour)}, false},
+		{"not tagged", SweepableResource{TimeCreated: now.Add(-2 * time.Hour)}, false},
+		{"unknown creation time", SweepableResource{FreeformTags: map[string]string{TestRunTagKey: "1"}}, false},
+	}
+	for _, test := range tests {
+		if got := filter.Matches(test.resource); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestUnitGetTagSweepOrder(t *testing.T) {
+	var deleted []string
+	setTagSweepers(t,
+		testTagSweeper("CoreVcn", "vcn", &deleted),
+		testTagSweeper("ObjectStorageBucket", "bucket", &deleted),
+		testTagSweeper("CoreInstance", "instance", &deleted),
+		testTagSweeper("CoreSubnet", "subnet", &deleted),
+	)
+
+	order, err := GetTagSweepOrder()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, sweeper := range order {
+		names = append(names, sweeper.Name)
+	}
+	if want := []string{"CoreInstance", "CoreSubnet", "CoreVcn", "ObjectStorageBucket"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected the order %v, got %v", want, names)
+	}
+}
+
+func TestUnitGetTagSweepOrderCycle(t *testing.T) {
+	graph := DependencyGraph
+	DependencyGraph = map[string][]string{"a": {"B"}, "b": {"A"}}
+	defer func() { DependencyGraph = graph }()
+
+	var deleted []string
+	setTagSweepers(t, testTagSweeper("A", "a", &deleted), testTagSweeper("B", "b", &deleted), testTagSweeper("C", "c", &deleted))
+
+	if _, err := GetTagSweepOrder(); err == nil || !strings.Contains(err.Error(), "A, B are cyclic") {
+		t.Errorf("expected a cyclic dependencies error, got %v", err)
+	}
+}
+
+func TestUnitSweepByTagAndAge(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	tags := map[string]string{TestRunTagKey: "1"}
+	var deleted []string
+	setTagSweepers(t,
+		testTagSweeper("CoreVcn", "vcn", &deleted,
+			SweepableResource{Id: "vcn1", FreeformTags: tags, TimeCreated: old},
+			SweepableResource{Id: "vcn2", TimeCreated: old}),
+		testTagSweeper("CoreSubnet", "subnet", &deleted,
+			SweepableResource{Id: "failing-subnet1", FreeformTags: tags, TimeCreated: old},
+			SweepableResource{Id: "subnet2", FreeformTags: tags, TimeCreated: time.Now()}),
+		testTagSweeper("CoreInstance", "instance", &deleted,
+			SweepableResource{Id: "instance1", FreeformTags: tags, TimeCreated: old}),
+	)
+	t.Setenv("TF_VAR_sweep_tag", "")
+	t.Setenv("TF_VAR_sweep_min_age", "")
+
+	t.Setenv("TF_VAR_sweep_dry_run", "true")
+	if err := SweepByTagAndAge("compartment"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected the dry run not to delete any resource, got %v", deleted)
+	}
+
+	t.Setenv("TF_VAR_sweep_dry_run", "false")
+	err := SweepByTagAndAge("compartment")
+	if want := []string{"instance1", "failing-subnet1", "vcn1"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("expected the deletions %v, got %v", want, deleted)
+	}
+	if err == nil || !strings.Contains(err.Error(), "unable to delete 1 of 3 resources") || !strings.Contains(err.Error(), "CoreSubnet failing-subnet1") {
+		t.Errorf("expected the failure of the subnet deletion, got %v", err)
+	}
+}
+
+func TestUnitTagTestRunResources(t *testing.T) {
+	defaultTags := tf_resource.DefaultFreeformTags
+	defer func() { tf_resource.DefaultFreeformTags = defaultTags }()
+
+	tf_resource.DefaultFreeformTags = map[string]interface{}{"Owner": "ci"}
+	t.Setenv("TF_VAR_sweep_tag_resources", "")
+	tagTestRunResources()
+	if want := map[string]interface{}{"Owner": "ci"}; !reflect.DeepEqual(tf_resource.DefaultFreeformTags, want) {
+		t.Errorf("expected the default tags %v, got %v", want, tf_resource.DefaultFreeformTags)
+	}
+
+	t.Setenv("TF_VAR_sweep_tag_resources", "true")
+	tagTestRunResources()
+	if want := map[string]interface{}{"Owner": "ci", TestRunTagKey: TestRunId}; !reflect.DeepEqual(tf_resource.DefaultFreeformTags, want) {
+		t.Errorf("expected the default tags %v, got %v", want, tf_resource.DefaultFreeformTags)
+	}
+}
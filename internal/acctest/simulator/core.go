@@ -0,0 +1,298 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+func init() {
+	registerResourceKind(&resourceKind{
+		collection:   "vcns",
+		resourceType: "vcn",
+		createdState: "AVAILABLE",
+		deletedState: "TERMINATED",
+		filters:      []string{"compartmentId", "displayName"},
+		create:       createVcn,
+		delete:       deleteVcn,
+	})
+	registerResourceKind(&resourceKind{
+		collection:   "subnets",
+		resourceType: "subnet",
+		createdState: "AVAILABLE",
+		deletedState: "TERMINATED",
+		filters:      []string{"compartmentId", "vcnId", "displayName"},
+		create:       createSubnet,
+		delete:       deleteSubnet,
+	})
+	registerResourceKind(&resourceKind{
+		collection:   "instances",
+		resourceType: "instance",
+		createdState: "RUNNING",
+		deletedState: "TERMINATED",
+		workRequests: true,
+		filters:      []string{"compartmentId", "availabilityDomain", "displayName"},
+		create:       launchInstance,
+		delete:       terminateInstance,
+		actions: map[string]func(s *Simulator, resource jsonObject) error{
+			"START":     instanceAction("RUNNING"),
+			"STOP":      instanceAction("STOPPED"),
+			"SOFTSTOP":  instanceAction("STOPPED"),
+			"RESET":     instanceAction("RUNNING"),
+			"SOFTRESET": instanceAction("RUNNING"),
+		},
+	})
+	registerResourceKind(vnicKind)
+	registerResourceKind(vnicAttachmentKind)
+	registerResourceKind(bootVolumeKind)
+	registerResourceKind(bootVolumeAttachmentKind)
+}
+
+// The VNICs and the boot volumes and their attachments are created with the instances
+var (
+	vnicKind = &resourceKind{
+		collection:   "vnics",
+		resourceType: "vnic",
+		createdState: "AVAILABLE",
+		deletedState: "TERMINATED",
+	}
+	vnicAttachmentKind = &resourceKind{
+		collection:   "vnicAttachments",
+		resourceType: "vnicattachment",
+		createdState: "ATTACHED",
+		deletedState: "DETACHED",
+		filters:      []string{"compartmentId", "availabilityDomain", "instanceId", "vnicId"},
+	}
+	bootVolumeKind = &resourceKind{
+		collection:   "bootVolumes",
+		resourceType: "bootvolume",
+		createdState: "AVAILABLE",
+		deletedState: "TERMINATED",
+		filters:      []string{"compartmentId", "availabilityDomain"},
+	}
+	bootVolumeAttachmentKind = &resourceKind{
+		collection:   "bootVolumeAttachments",
+		resourceType: "bootvolumeattachment",
+		createdState: "ATTACHED",
+		deletedState: "DETACHED",
+		filters:      []string{"compartmentId", "availabilityDomain", "instanceId", "bootVolumeId"},
+	}
+)
+
+func createVcn(s *Simulator, vcn jsonObject) error {
+	cidrBlocks, _ := vcn["cidrBlocks"].([]interface{})
+	if cidrBlock := stringValue(vcn, "cidrBlock"); cidrBlock != "" && len(cidrBlocks) == 0 {
+		cidrBlocks = []interface{}{cidrBlock}
+	}
+	if len(cidrBlocks) == 0 {
+		return errInvalidParameter("cidrBlock or cidrBlocks is required")
+	}
+	for _, cidrBlock := range cidrBlocks {
+		if _, _, err := net.ParseCIDR(fmt.Sprint(cidrBlock)); err != nil {
+			return errInvalidParameter("invalid CIDR block %v", cidrBlock)
+		}
+	}
+	if stringValue(vcn, "compartmentId") == "" {
+		return errInvalidParameter("compartmentId is required")
+	}
+
+	vcn["cidrBlocks"] = cidrBlocks
+	vcn["cidrBlock"] = cidrBlocks[0]
+	vcn["defaultDhcpOptionsId"] = s.newId("dhcpoptions")
+	vcn["defaultRouteTableId"] = s.newId("routetable")
+	vcn["defaultSecurityListId"] = s.newId("securitylist")
+	if dnsLabel := stringValue(vcn, "dnsLabel"); dnsLabel != "" {
+		vcn["vcnDomainName"] = dnsLabel + ".oraclevcn.com"
+	}
+	return nil
+}
+
+func deleteVcn(s *Simulator, vcn jsonObject, query url.Values) error {
+	if subnets := s.list(resourceKinds["subnets"], url.Values{"vcnId": {stringValue(vcn, "id")}}); len(subnets) > 0 {
+		return errConflict("Conflict", "the VCN %s has %d subnets", stringValue(vcn, "id"), len(subnets))
+	}
+	return nil
+}
+
+func createSubnet(s *Simulator, subnet jsonObject) error {
+	vcn, err := s.get(resourceKinds["vcns"], stringValue(subnet, "vcnId"))
+	if err != nil {
+		return err
+	}
+	_, network, err := net.ParseCIDR(stringValue(subnet, "cidrBlock"))
+	if err != nil {
+		return errInvalidParameter("invalid CIDR block %s", stringValue(subnet, "cidrBlock"))
+	}
+
+	if _, ok := subnet["routeTableId"]; !ok {
+		subnet["routeTableId"] = vcn["defaultRouteTableId"]
+	}
+	if _, ok := subnet["securityListIds"]; !ok {
+		subnet["securityListIds"] = []interface{}{vcn["defaultSecurityListId"]}
+	}
+	if _, ok := subnet["dhcpOptionsId"]; !ok {
+		subnet["dhcpOptionsId"] = vcn["defaultDhcpOptionsId"]
+	}
+	if _, ok := subnet["prohibitPublicIpOnVnic"]; !ok {
+		subnet["prohibitPublicIpOnVnic"] = false
+	}
+	if _, ok := subnet["prohibitInternetIngress"]; !ok {
+		subnet["prohibitInternetIngress"] = subnet["prohibitPublicIpOnVnic"]
+	}
+	if dnsLabel, vcnDomainName := stringValue(subnet, "dnsLabel"), stringValue(vcn, "vcnDomainName"); dnsLabel != "" && vcnDomainName != "" {
+		subnet["subnetDomainName"] = dnsLabel + "." + vcnDomainName
+	}
+	subnet["virtualRouterIp"] = hostAddress(network, 1)
+	subnet["virtualRouterMac"] = "00:00:17:00:00:01"
+	return nil
+}
+
+func deleteSubnet(s *Simulator, subnet jsonObject, query url.Values) error {
+	for _, vnic := range s.list(vnicKind, url.Values{}) {
+		if stringValue(vnic, "subnetId") == stringValue(subnet, "id") {
+			return errConflict("Conflict", "the subnet %s has attached VNICs", stringValue(subnet, "id"))
+		}
+	}
+	return nil
+}
+
+// launchInstance creates an instance with its primary VNIC in the subnet of the create VNIC details and its boot volume
+func launchInstance(s *Simulator, instance jsonObject) error {
+	for _, field := range []string{"availabilityDomain", "compartmentId", "shape"} {
+		if stringValue(instance, field) == "" {
+			return errInvalidParameter("%s is required", field)
+		}
+	}
+	vnicDetails, _ := instance["createVnicDetails"].(map[string]interface{})
+	subnetId := stringValue(vnicDetails, "subnetId")
+	if subnetId == "" {
+		subnetId = stringValue(instance, "subnetId")
+	}
+	if subnetId == "" {
+		return errInvalidParameter("createVnicDetails.subnetId is required")
+	}
+	subnet, err := s.get(resourceKinds["subnets"], subnetId)
+	if err != nil {
+		return err
+	}
+
+	sourceDetails, _ := instance["sourceDetails"].(map[string]interface{})
+	if sourceDetails == nil {
+		sourceDetails = map[string]interface{}{"sourceType": "image", "imageId": instance["imageId"]}
+		instance["sourceDetails"] = sourceDetails
+	}
+	if stringValue(sourceDetails, "sourceType") == "image" {
+		instance["imageId"] = sourceDetails["imageId"]
+	}
+	if _, ok := instance["faultDomain"]; !ok {
+		instance["faultDomain"] = "FAULT-DOMAIN-1"
+	}
+	instance["region"] = s.Region
+	delete(instance, "createVnicDetails")
+	delete(instance, "subnetId")
+
+	vnic := s.newDependent(vnicKind, instance)
+	vnic["subnetId"] = subnetId
+	vnic["isPrimary"] = true
+	for _, field := range []string{"displayName", "hostnameLabel", "privateIp", "freeformTags", "definedTags", "nsgIds"} {
+		if value, ok := vnicDetails[field]; ok {
+			vnic[field] = value
+		}
+	}
+	vnic["macAddress"] = fmt.Sprintf("02:00:17:%02x:%02x:%02x", byte(s.count>>16), byte(s.count>>8), byte(s.count))
+	vnic["skipSourceDestCheck"] = false
+	if stringValue(vnic, "privateIp") == "" {
+		// The first address of the subnet is the virtual router's, the next ones are assigned in order
+		_, network, _ := net.ParseCIDR(stringValue(subnet, "cidrBlock"))
+		vnics := 0
+		for _, existing := range s.collections[vnicKind.collection] {
+			if stringValue(existing, "subnetId") == subnetId {
+				vnics++
+			}
+		}
+		vnic["privateIp"] = hostAddress(network, 2+vnics)
+	}
+	if assignPublicIp, ok := vnicDetails["assignPublicIp"].(bool); (ok && assignPublicIp) || (!ok && subnet["prohibitPublicIpOnVnic"] == false) {
+		vnic["publicIp"] = fmt.Sprintf("203.0.113.%d", 1+len(s.collections[vnicKind.collection])%254)
+	}
+	s.store(vnicKind, vnic)
+
+	vnicAttachment := s.newDependent(vnicAttachmentKind, instance)
+	vnicAttachment["instanceId"] = instance["id"]
+	vnicAttachment["vnicId"] = vnic["id"]
+	vnicAttachment["subnetId"] = subnetId
+	vnicAttachment["nicIndex"] = 0
+	s.store(vnicAttachmentKind, vnicAttachment)
+
+	sizeInGBs := json.Number("47")
+	if size, ok := sourceDetails["bootVolumeSizeInGBs"].(json.Number); ok {
+		sizeInGBs = size
+	}
+	bootVolume := s.newDependent(bootVolumeKind, instance)
+	bootVolume["displayName"] = fmt.Sprintf("%s (Boot Volume)", stringValue(instance, "displayName"))
+	bootVolume["imageId"] = instance["imageId"]
+	bootVolume["sizeInGBs"] = sizeInGBs
+	bootVolume["vpusPerGB"] = json.Number("10")
+	s.store(bootVolumeKind, bootVolume)
+
+	bootVolumeAttachment := s.newDependent(bootVolumeAttachmentKind, instance)
+	bootVolumeAttachment["instanceId"] = instance["id"]
+	bootVolumeAttachment["bootVolumeId"] = bootVolume["id"]
+	s.store(bootVolumeAttachmentKind, bootVolumeAttachment)
+	return nil
+}
+
+// terminateInstance detaches and terminates the VNICs of an instance, and its boot volume unless preserveBootVolume is true
+func terminateInstance(s *Simulator, instance jsonObject, query url.Values) error {
+	instanceId := stringValue(instance, "id")
+	for _, vnicAttachment := range s.collections[vnicAttachmentKind.collection] {
+		if stringValue(vnicAttachment, "instanceId") == instanceId {
+			vnicAttachment["lifecycleState"] = vnicAttachmentKind.deletedState
+			s.setState(vnicKind.collection, "id", stringValue(vnicAttachment, "vnicId"), vnicKind.deletedState)
+		}
+	}
+	for _, bootVolumeAttachment := range s.collections[bootVolumeAttachmentKind.collection] {
+		if stringValue(bootVolumeAttachment, "instanceId") == instanceId {
+			bootVolumeAttachment["lifecycleState"] = bootVolumeAttachmentKind.deletedState
+			if query.Get("preserveBootVolume") != "true" {
+				s.setState(bootVolumeKind.collection, "id", stringValue(bootVolumeAttachment, "bootVolumeId"), bootVolumeKind.deletedState)
+			}
+		}
+	}
+	return nil
+}
+
+func instanceAction(state string) func(s *Simulator, instance jsonObject) error {
+	return func(s *Simulator, instance jsonObject) error {
+		if stringValue(instance, "lifecycleState") == "TERMINATED" {
+			return errConflict("IncorrectState", "the instance %s is terminated", stringValue(instance, "id"))
+		}
+		instance["lifecycleState"] = state
+		return nil
+	}
+}
+
+// newDependent returns a new resource of a kind created along with a parent resource, in its compartment and availability domain
+func (s *Simulator) newDependent(kind *resourceKind, parent jsonObject) jsonObject {
+	return jsonObject{
+		"id":                 s.newId(kind.resourceType),
+		"compartmentId":      parent["compartmentId"],
+		"availabilityDomain": parent["availabilityDomain"],
+		"lifecycleState":     kind.createdState,
+		"timeCreated":        now(),
+	}
+}
+
+// hostAddress returns the address of the nth host of an IPv4 network e.g. 10.0.0.1 for the first host of 10.0.0.0/24
+func hostAddress(network *net.IPNet, n int) string {
+	ip := network.IP.To4()
+	if ip == nil {
+		return ""
+	}
+	address := (uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])) + uint32(n)
+	return net.IPv4(byte(address>>24), byte(address>>16), byte(address>>8), byte(address)).String()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package simulator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func init() {
+	registerResourceKind(&resourceKind{
+		collection:   "compartments",
+		resourceType: "compartment",
+		createdState: "ACTIVE",
+		deletedState: "DELETED",
+		workRequests: true,
+		uniqueName:   "name",
+		filters:      []string{"compartmentId", "name"},
+		create:       createCompartment,
+		delete:       deleteCompartment,
+	})
+	registerResourceKind(&resourceKind{
+		collection:   "policies",
+		resourceType: "policy",
+		createdState: "ACTIVE",
+		deletedState: "DELETED",
+		uniqueName:   "name",
+		filters:      []string{"compartmentId", "name"},
+		create:       createPolicy,
+	})
+}
+
+func createCompartment(s *Simulator, compartment jsonObject) error {
+	for _, field := range []string{"compartmentId", "name", "description"} {
+		if stringValue(compartment, field) == "" {
+			return errInvalidParameter("%s is required", field)
+		}
+	}
+	compartment["isAccessible"] = true
+	return nil
+}
+
+// deleteCompartment fails for the compartments that have active resources, as the identity service does
+func deleteCompartment(s *Simulator, compartment jsonObject, query url.Values) error {
+	compartmentId := stringValue(compartment, "id")
+	for collection, kind := range resourceKinds {
+		for _, resource := range s.list(kind, url.Values{}) {
+			if stringValue(resource, "compartmentId") == compartmentId {
+				return errConflict("CompartmentNotEmpty", "the compartment %s has active %s", compartmentId, collection)
+			}
+		}
+	}
+	for name, bucket := range s.buckets {
+		if stringValue(bucket, "compartmentId") == compartmentId {
+			return errConflict("CompartmentNotEmpty", "the compartment %s has the bucket %s", compartmentId, name)
+		}
+	}
+	return nil
+}
+
+func createPolicy(s *Simulator, policy jsonObject) error {
+	for _, field := range []string{"compartmentId", "name", "description"} {
+		if stringValue(policy, field) == "" {
+			return errInvalidParameter("%s is required", field)
+		}
+	}
+	if statements, _ := policy["statements"].([]interface{}); len(statements) == 0 {
+		return errInvalidParameter("statements is required")
+	}
+	return nil
+}
+
+// serveAvailabilityDomains lists three availability domains in the region of the simulator
+func (s *Simulator) serveAvailabilityDomains(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, errNotImplemented(r))
+		return
+	}
+	compartmentId := r.URL.Query().Get("compartmentId")
+	availabilityDomains := []jsonObject{}
+	for index := 1; index <= 3; index++ {
+		availabilityDomains = append(availabilityDomains, jsonObject{
+			"id":            fmt.Sprintf("ocid1.availabilitydomain.oc1..sim%d", index),
+			"name":          fmt.Sprintf("Sim:%s-AD-%d", strings.ToUpper(s.Region), index),
+			"compartmentId": compartmentId,
+		})
+	}
+	writeJSON(w, http.StatusOK, availabilityDomains)
+}
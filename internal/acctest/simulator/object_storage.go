@@ -0,0 +1,298 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package simulator
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// storedObjectHeaders are the headers of the put object requests that are returned with the object
+var storedObjectHeaders = []string{"Content-Type", "Content-Language", "Content-Encoding", "Content-Disposition", "Cache-Control"}
+
+// storedObject is an object in a bucket
+type storedObject struct {
+	content      []byte
+	headers      http.Header
+	etag         string
+	timeCreated  time.Time
+	timeModified time.Time
+}
+
+// serveObjectStorage serves the namespace, the buckets and the objects of the object storage API under /n/
+func (s *Simulator) serveObjectStorage(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 {
+		if r.Method != http.MethodGet {
+			writeError(w, errNotImplemented(r))
+			return
+		}
+		writeJSON(w, http.StatusOK, s.Namespace)
+		return
+	}
+	if segments[0] != s.Namespace {
+		writeError(w, errNotFound("namespace %s not found", segments[0]))
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, jsonObject{"namespace": s.Namespace, "defaultS3CompartmentId": s.TenancyId, "defaultSwiftCompartmentId": s.TenancyId})
+	case len(segments) == 2 && segments[1] == "b":
+		s.serveBuckets(w, r)
+	case len(segments) >= 3 && segments[1] == "b":
+		bucketName, _ := url.PathUnescape(segments[2])
+		bucket, ok := s.buckets[bucketName]
+		if !ok {
+			writeError(w, &apiError{status: http.StatusNotFound, code: "BucketNotFound", message: "bucket " + bucketName + " not found"})
+			return
+		}
+		switch {
+		case len(segments) == 3:
+			s.serveBucket(w, r, bucket)
+		case len(segments) == 4 && segments[3] == "retentionRules" && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, jsonObject{"items": []jsonObject{}})
+		case len(segments) == 4 && segments[3] == "o" && r.Method == http.MethodGet:
+			s.listObjects(w, r, bucketName)
+		case len(segments) > 4 && segments[3] == "o":
+			objectName, err := url.PathUnescape(strings.Join(segments[4:], "/"))
+			if err != nil {
+				writeError(w, errInvalidParameter("invalid object name: %v", err))
+				return
+			}
+			s.serveObject(w, r, bucketName, objectName)
+		case len(segments) == 5 && segments[3] == "actions" && segments[4] == "renameObject" && r.Method == http.MethodPost:
+			s.renameObject(w, r, bucketName)
+		default:
+			writeError(w, errNotImplemented(r))
+		}
+	default:
+		writeError(w, errNotImplemented(r))
+	}
+}
+
+func (s *Simulator) serveBuckets(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		compartmentId := r.URL.Query().Get("compartmentId")
+		buckets := []jsonObject{}
+		for _, bucket := range s.buckets {
+			if stringValue(bucket, "compartmentId") == compartmentId {
+				buckets = append(buckets, bucket)
+			}
+		}
+		sort.Slice(buckets, func(i, j int) bool {
+			return stringValue(buckets[i], "name") < stringValue(buckets[j], "name")
+		})
+		writeJSON(w, http.StatusOK, buckets)
+	case http.MethodPost:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		name := stringValue(body, "name")
+		if name == "" || stringValue(body, "compartmentId") == "" {
+			writeError(w, errInvalidParameter("name and compartmentId are required"))
+			return
+		}
+		if _, ok := s.buckets[name]; ok {
+			writeError(w, errConflict("BucketAlreadyExists", "either the bucket %s in namespace %s already exists or you are not authorized to create it", name, s.Namespace))
+			return
+		}
+
+		bucket := jsonObject{
+			"publicAccessType":    "NoPublicAccess",
+			"storageTier":         "Standard",
+			"objectEventsEnabled": false,
+			"versioning":          "Disabled",
+			"autoTiering":         "Disabled",
+			"replicationEnabled":  false,
+			"isReadOnly":          false,
+			"metadata":            jsonObject{},
+			"freeformTags":        jsonObject{},
+			"definedTags":         jsonObject{},
+		}
+		for key, value := range body {
+			bucket[key] = value
+		}
+		bucket["namespace"] = s.Namespace
+		bucket["id"] = s.newId("bucket")
+		bucket["createdBy"] = userId
+		bucket["timeCreated"] = now()
+		bucket["etag"] = s.newETag()
+		s.buckets[name] = bucket
+		s.objects[name] = map[string]*storedObject{}
+		s.writeBucket(w, bucket)
+	default:
+		writeError(w, errNotImplemented(r))
+	}
+}
+
+func (s *Simulator) serveBucket(w http.ResponseWriter, r *http.Request, bucket jsonObject) {
+	name := stringValue(bucket, "name")
+	switch r.Method {
+	case http.MethodGet:
+		s.writeBucket(w, bucket)
+	case http.MethodHead:
+		w.Header().Set("etag", stringValue(bucket, "etag"))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if newName := stringValue(body, "name"); newName != "" && newName != name {
+			if _, ok := s.buckets[newName]; ok {
+				writeError(w, errConflict("BucketAlreadyExists", "the bucket %s already exists", newName))
+				return
+			}
+			s.buckets[newName], s.objects[newName] = bucket, s.objects[name]
+			delete(s.buckets, name)
+			delete(s.objects, name)
+		}
+		for key, value := range body {
+			bucket[key] = value
+		}
+		bucket["etag"] = s.newETag()
+		s.writeBucket(w, bucket)
+	case http.MethodDelete:
+		if len(s.objects[name]) > 0 {
+			writeError(w, errConflict("BucketNotEmpty", "the bucket %s in namespace %s is not empty", name, s.Namespace))
+			return
+		}
+		delete(s.buckets, name)
+		delete(s.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, errNotImplemented(r))
+	}
+}
+
+func (s *Simulator) writeBucket(w http.ResponseWriter, bucket jsonObject) {
+	objects := s.objects[stringValue(bucket, "name")]
+	size := 0
+	for _, object := range objects {
+		size += len(object.content)
+	}
+	bucket["approximateCount"] = len(objects)
+	bucket["approximateSize"] = size
+	w.Header().Set("etag", stringValue(bucket, "etag"))
+	writeJSON(w, http.StatusOK, bucket)
+}
+
+// listObjects lists the objects of a bucket by name, filtered by the prefix of the query
+func (s *Simulator) listObjects(w http.ResponseWriter, r *http.Request, bucketName string) {
+	prefix := r.URL.Query().Get("prefix")
+	var names []string
+	for name := range s.objects[bucketName] {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	objects := []jsonObject{}
+	for _, name := range names {
+		object := s.objects[bucketName][name]
+		objects = append(objects, jsonObject{
+			"name":         name,
+			"size":         len(object.content),
+			"md5":          contentMD5(object.content),
+			"etag":         object.etag,
+			"timeCreated":  object.timeCreated.Format(time.RFC3339Nano),
+			"timeModified": object.timeModified.Format(time.RFC3339Nano),
+			"storageTier":  "Standard",
+		})
+	}
+	writeJSON(w, http.StatusOK, jsonObject{"objects": objects, "prefixes": []string{}})
+}
+
+func (s *Simulator) serveObject(w http.ResponseWriter, r *http.Request, bucketName string, objectName string) {
+	objects := s.objects[bucketName]
+	if r.Method == http.MethodPut {
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, errInvalidParameter("unable to read the object: %v", err))
+			return
+		}
+		object := &storedObject{content: content, headers: http.Header{}, etag: s.newETag(), timeCreated: time.Now().UTC()}
+		if existing, ok := objects[objectName]; ok {
+			object.timeCreated = existing.timeCreated
+		}
+		object.timeModified = time.Now().UTC()
+		for name, values := range r.Header {
+			if strings.HasPrefix(strings.ToLower(name), "opc-meta-") {
+				object.headers[name] = values
+			}
+		}
+		for _, name := range storedObjectHeaders {
+			if value := r.Header.Get(name); value != "" {
+				object.headers.Set(name, value)
+			}
+		}
+		if object.headers.Get("Content-Type") == "" {
+			object.headers.Set("Content-Type", "application/octet-stream")
+		}
+		objects[objectName] = object
+
+		w.Header().Set("etag", object.etag)
+		w.Header().Set("opc-content-md5", contentMD5(content))
+		w.Header().Set("last-modified", object.timeModified.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	object, ok := objects[objectName]
+	if !ok {
+		writeError(w, &apiError{status: http.StatusNotFound, code: "ObjectNotFound", message: "object " + objectName + " not found in bucket " + bucketName})
+		return
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		for name, values := range object.headers {
+			w.Header()[name] = values
+		}
+		w.Header().Set("etag", object.etag)
+		w.Header().Set("content-md5", contentMD5(object.content))
+		w.Header().Set("content-length", strconv.Itoa(len(object.content)))
+		w.Header().Set("last-modified", object.timeModified.Format(http.TimeFormat))
+		w.Header().Set("storage-tier", "Standard")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(object.content)
+		}
+	case http.MethodDelete:
+		delete(objects, objectName)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, errNotImplemented(r))
+	}
+}
+
+func (s *Simulator) renameObject(w http.ResponseWriter, r *http.Request, bucketName string) {
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	objects := s.objects[bucketName]
+	sourceName, newName := stringValue(body, "sourceName"), stringValue(body, "newName")
+	object, ok := objects[sourceName]
+	if !ok {
+		writeError(w, &apiError{status: http.StatusNotFound, code: "ObjectNotFound", message: "object " + sourceName + " not found in bucket " + bucketName})
+		return
+	}
+	delete(objects, sourceName)
+	objects[newName] = object
+	object.timeModified = time.Now().UTC()
+	w.Header().Set("etag", object.etag)
+	w.Header().Set("last-modified", object.timeModified.Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+}
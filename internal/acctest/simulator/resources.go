@@ -0,0 +1,256 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package simulator
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// resourceKind describes a collection of resources served with the generic create, read, update, delete and list operations
+type resourceKind struct {
+	// collection is the name of the collection in the paths e.g. vcns
+	collection string
+	// resourceType is the type of the resources in their OCIDs and in the work requests e.g. vcn
+	resourceType string
+	createdState string
+	deletedState string
+	// workRequests adds a work request to the responses of the create and delete operations
+	workRequests bool
+	// uniqueName is the field of the resources that has to be unique in a compartment e.g. name
+	uniqueName string
+	// filters are the query parameters filtering the lists by the fields of the same name e.g. vcnId
+	filters []string
+	// create validates a new resource and completes it e.g. with the default values, it is called with the lock held
+	create func(s *Simulator, resource jsonObject) error
+	// delete checks that a resource can be deleted and deletes its dependents, it is called with the lock held
+	delete func(s *Simulator, resource jsonObject, query url.Values) error
+	// actions are the operations called with POST /<collection>/<id>?action=<name>
+	actions map[string]func(s *Simulator, resource jsonObject) error
+}
+
+// resourceKinds are the kinds of the resources served by the simulator, by collection
+var resourceKinds = map[string]*resourceKind{}
+
+func registerResourceKind(kind *resourceKind) {
+	resourceKinds[kind.collection] = kind
+}
+
+// serveResource serves the operations on a collection:
+//
+//	GET    /<collection>                          list
+//	POST   /<collection>                          create
+//	GET    /<collection>/<id>                     get
+//	PUT    /<collection>/<id>                     update
+//	DELETE /<collection>/<id>                     delete
+//	POST   /<collection>/<id>?action=<name>       action
+//	POST   /<collection>/<id>/actions/<name>      changeCompartment or moveCompartment
+func (s *Simulator) serveResource(w http.ResponseWriter, r *http.Request, kind *resourceKind, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.list(kind, r.URL.Query()))
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		resource, err := s.create(kind, body)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		s.writeResource(w, kind, resource, "CREATED")
+	case len(segments) == 1:
+		resource, err := s.get(kind, segments[0])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			s.writeResource(w, kind, resource, "")
+		case http.MethodPut:
+			body, err := readBody(r)
+			if err == nil {
+				err = s.update(kind, resource, body)
+			}
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			s.writeResource(w, kind, resource, "")
+		case http.MethodDelete:
+			if err := s.delete(kind, resource, r.URL.Query()); err != nil {
+				writeError(w, err)
+				return
+			}
+			if kind.workRequests {
+				w.Header().Set("opc-work-request-id", s.newWorkRequest(kind, resource, "DELETED"))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			action, ok := kind.actions[r.URL.Query().Get("action")]
+			if !ok {
+				writeError(w, errNotImplemented(r))
+				return
+			}
+			if err := action(s, resource); err != nil {
+				writeError(w, err)
+				return
+			}
+			resource["etag"] = s.newETag()
+			s.writeResource(w, kind, resource, "")
+		default:
+			writeError(w, errNotImplemented(r))
+		}
+	case len(segments) == 3 && segments[1] == "actions" && r.Method == http.MethodPost:
+		resource, err := s.get(kind, segments[0])
+		if err == nil {
+			err = s.changeCompartment(resource, segments[2], r)
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("opc-work-request-id", s.newWorkRequest(kind, resource, "UPDATED"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, errNotImplemented(r))
+	}
+}
+
+// writeResource writes a resource with its etag, and the work request of the action when the kind has work requests
+func (s *Simulator) writeResource(w http.ResponseWriter, kind *resourceKind, resource jsonObject, action string) {
+	w.Header().Set("etag", stringValue(resource, "etag"))
+	if kind.workRequests && action != "" {
+		w.Header().Set("opc-work-request-id", s.newWorkRequest(kind, resource, action))
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+// list returns the resources of a kind matching the filters of the query, the deleted resources are only listed when
+// they are filtered by their lifecycle state
+func (s *Simulator) list(kind *resourceKind, query url.Values) []jsonObject {
+	items := []jsonObject{}
+	for _, resource := range s.collections[kind.collection] {
+		if state := query.Get("lifecycleState"); state != "" {
+			if stringValue(resource, "lifecycleState") != state {
+				continue
+			}
+		} else if kind.deletedState != "" && stringValue(resource, "lifecycleState") == kind.deletedState {
+			continue
+		}
+		if matchesFilters(resource, kind.filters, query) {
+			items = append(items, resource)
+		}
+	}
+	return items
+}
+
+func matchesFilters(resource jsonObject, filters []string, query url.Values) bool {
+	for _, filter := range filters {
+		if value := query.Get(filter); value != "" && stringValue(resource, filter) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// create stores a new resource of a kind from the body of a create request
+func (s *Simulator) create(kind *resourceKind, body jsonObject) (jsonObject, error) {
+	if kind.uniqueName != "" {
+		name := stringValue(body, kind.uniqueName)
+		for _, resource := range s.list(kind, url.Values{"compartmentId": {stringValue(body, "compartmentId")}}) {
+			if name != "" && stringValue(resource, kind.uniqueName) == name {
+				return nil, errConflict("Conflict", "a %s with the %s '%s' already exists in the compartment", kind.resourceType, kind.uniqueName, name)
+			}
+		}
+	}
+
+	resource := jsonObject{}
+	for key, value := range body {
+		resource[key] = value
+	}
+	resource["id"] = s.newId(kind.resourceType)
+	resource["lifecycleState"] = kind.createdState
+	resource["timeCreated"] = now()
+	resource["etag"] = s.newETag()
+	if kind.create != nil {
+		if err := kind.create(s, resource); err != nil {
+			return nil, err
+		}
+	}
+	s.store(kind, resource)
+	return resource, nil
+}
+
+// store adds a complete resource to its collection, it is used by the kinds creating dependent resources
+func (s *Simulator) store(kind *resourceKind, resource jsonObject) {
+	s.collections[kind.collection] = append(s.collections[kind.collection], resource)
+}
+
+// get returns the resource of a kind with an id, the deleted resources are returned in their deleted state
+func (s *Simulator) get(kind *resourceKind, id string) (jsonObject, error) {
+	for _, resource := range s.collections[kind.collection] {
+		if stringValue(resource, "id") == id {
+			return resource, nil
+		}
+	}
+	return nil, errNotFound("%s %s not found", kind.resourceType, id)
+}
+
+// update merges the fields of the body of an update request into a resource
+func (s *Simulator) update(kind *resourceKind, resource jsonObject, body jsonObject) error {
+	if kind.deletedState != "" && stringValue(resource, "lifecycleState") == kind.deletedState {
+		return errNotFound("%s %s not found", kind.resourceType, stringValue(resource, "id"))
+	}
+	for key, value := range body {
+		resource[key] = value
+	}
+	resource["etag"] = s.newETag()
+	return nil
+}
+
+// delete moves a resource to the deleted state of its kind
+func (s *Simulator) delete(kind *resourceKind, resource jsonObject, query url.Values) error {
+	if stringValue(resource, "lifecycleState") == kind.deletedState {
+		return errNotFound("%s %s not found", kind.resourceType, stringValue(resource, "id"))
+	}
+	if kind.delete != nil {
+		if err := kind.delete(s, resource, query); err != nil {
+			return err
+		}
+	}
+	resource["lifecycleState"] = kind.deletedState
+	return nil
+}
+
+// changeCompartment moves a resource to the compartment in the body of a changeCompartment or moveCompartment action
+func (s *Simulator) changeCompartment(resource jsonObject, action string, r *http.Request) error {
+	field := map[string]string{"changeCompartment": "compartmentId", "moveCompartment": "targetCompartmentId"}[action]
+	if field == "" {
+		return errNotImplemented(r)
+	}
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	compartmentId := stringValue(body, field)
+	if compartmentId == "" {
+		return errInvalidParameter("%s is required", field)
+	}
+	resource["compartmentId"] = compartmentId
+	resource["etag"] = s.newETag()
+	return nil
+}
+
+// setState sets the lifecycle state of the resources of a kind with a field value e.g. the vnic attachments of an instance
+func (s *Simulator) setState(collection string, field string, value string, state string) {
+	for _, resource := range s.collections[collection] {
+		if stringValue(resource, field) == value {
+			resource["lifecycleState"] = state
+		}
+	}
+}
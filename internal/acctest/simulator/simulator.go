@@ -0,0 +1,266 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+// Package simulator provides an in-process fake of the OCI control plane, so that the provider resources can be
+// exercised end-to-end by the acceptance tests without a tenancy or network access.
+package simulator
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tf_client "github.com/oracle/terraform-provider-oci/internal/client"
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+)
+
+const (
+	defaultRegion    = "us-phoenix-1"
+	defaultNamespace = "simulator"
+	userId           = "ocid1.user.oc1..simulator"
+	fingerprint      = "00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00"
+)
+
+// jsonObject is a resource as it is sent and received by the API
+type jsonObject = map[string]interface{}
+
+// Simulator serves the OCI APIs of the core VCNs, subnets and instances, the identity compartments and policies,
+// the object storage buckets and objects and the work requests from memory.
+// The requests are not authenticated, any signing key is accepted.
+type Simulator struct {
+	TenancyId string
+	Region    string
+	Namespace string
+
+	server       *httptest.Server
+	lock         sync.Mutex
+	count        int
+	collections  map[string][]jsonObject
+	buckets      map[string]jsonObject
+	objects      map[string]map[string]*storedObject
+	workRequests map[string]jsonObject
+}
+
+// NewSimulator starts a simulator listening on a local port, it has to be closed once the tests are done
+func NewSimulator() *Simulator {
+	s := &Simulator{
+		TenancyId:    "ocid1.tenancy.oc1..simulator",
+		Region:       defaultRegion,
+		Namespace:    defaultNamespace,
+		collections:  map[string][]jsonObject{},
+		buckets:      map[string]jsonObject{},
+		objects:      map[string]map[string]*storedObject{},
+		workRequests: map[string]jsonObject{},
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the base URL of the simulator e.g. http://127.0.0.1:40000
+func (s *Simulator) URL() string {
+	return s.server.URL
+}
+
+// Close stops the simulator
+func (s *Simulator) Close() {
+	s.server.Close()
+}
+
+// ClientHostOverrides returns the value of the CLIENT_HOST_OVERRIDES environment variable pointing all the clients of
+// the provider at the simulator, so that no request leaves the machine
+func (s *Simulator) ClientHostOverrides() string {
+	names := []string{tf_client.WorkRequestClientName}
+	if tf_client.OracleClientRegistrationsVar != nil {
+		for name := range tf_client.OracleClientRegistrationsVar.RegisteredClients {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	overrides := make([]string, len(names))
+	for index, name := range names {
+		overrides[index] = name + globalvar.EqualToOperatorDelimiter + s.URL()
+	}
+	return strings.Join(overrides, globalvar.ColonDelimiter)
+}
+
+// Setenv sets the environment variables read by acctest.GetTestClients and acctest.PreCheck to the simulator for the
+// duration of the test: the host overrides, the tenancy, the region, a generated API signing key and the compartments
+func (s *Simulator) Setenv(t testing.TB) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate the API signing key: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	settings := map[string]string{
+		globalvar.ClientHostOverridesEnv: s.ClientHostOverrides(),
+		"auth":                           globalvar.AuthAPIKeySetting,
+		"tenancy_ocid":                   s.TenancyId,
+		"user_ocid":                      userId,
+		"fingerprint":                    fingerprint,
+		"private_key":                    string(privateKey),
+		"private_key_path":               "",
+		"private_key_password":           "",
+		"region":                         s.Region,
+		"compartment_ocid":               s.TenancyId,
+		"compartment_id_for_create":      s.TenancyId,
+		"compartment_id_for_update":      s.TenancyId,
+		"tags_import_if_exists":          "false",
+	}
+	// The TF_VAR_ settings take precedence over the OCI_ and unprefixed ones that may be set on the machine
+	for name, value := range settings {
+		t.Setenv(globalvar.TfEnvPrefix+name, value)
+	}
+}
+
+// ServeHTTP routes the requests by the first segment of their path after the API version
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	segments := splitPath(r.URL.EscapedPath())
+	if len(segments) > 0 && isAPIVersion(segments[0]) {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		writeError(w, errNotImplemented(r))
+		return
+	}
+
+	switch segments[0] {
+	case "n":
+		s.serveObjectStorage(w, r, segments[1:])
+	case "workRequests":
+		s.serveWorkRequests(w, r, segments[1:])
+	case "availabilityDomains":
+		s.serveAvailabilityDomains(w, r)
+	default:
+		kind, ok := resourceKinds[segments[0]]
+		if !ok {
+			writeError(w, errNotImplemented(r))
+			return
+		}
+		s.serveResource(w, r, kind, segments[1:])
+	}
+}
+
+// newId returns a new OCID of a resource type e.g. ocid1.vcn.oc1.us-phoenix-1.sim00000001
+func (s *Simulator) newId(resourceType string) string {
+	s.count++
+	return fmt.Sprintf("ocid1.%s.oc1.%s.sim%08d", resourceType, s.Region, s.count)
+}
+
+// newETag returns a new entity tag for a created or updated resource
+func (s *Simulator) newETag() string {
+	s.count++
+	return fmt.Sprintf("sim-etag-%08d", s.count)
+}
+
+// apiError is an error returned in the body of the responses as the OCI services do
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.status, e.code, e.message)
+}
+
+func errNotFound(format string, args ...interface{}) *apiError {
+	return &apiError{status: http.StatusNotFound, code: "NotAuthorizedOrNotFound", message: fmt.Sprintf(format, args...)}
+}
+
+func errConflict(code string, format string, args ...interface{}) *apiError {
+	return &apiError{status: http.StatusConflict, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func errInvalidParameter(format string, args ...interface{}) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: "InvalidParameter", message: fmt.Sprintf(format, args...)}
+}
+
+// errNotImplemented is returned with a 400 status, the provider retries the requests failing with a 404 until they time out
+func errNotImplemented(r *http.Request) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: "NotImplemented", message: fmt.Sprintf("%s %s is not supported by the simulator", r.Method, r.URL.Path)}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = &apiError{status: http.StatusInternalServerError, code: "InternalServerError", message: err.Error()}
+	}
+	writeJSON(w, e.status, map[string]string{"code": e.code, "message": e.message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		status = http.StatusInternalServerError
+		content = []byte(fmt.Sprintf(`{"code":"InternalServerError","message":%q}`, err.Error()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("opc-request-id", fmt.Sprintf("sim-%d", time.Now().UnixNano()))
+	w.WriteHeader(status)
+	w.Write(content)
+}
+
+// readBody decodes the JSON object in the body of a request, an empty body is an empty object
+func readBody(r *http.Request) (jsonObject, error) {
+	body := jsonObject{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil && err.Error() != "EOF" {
+		return nil, errInvalidParameter("the body is not a JSON object: %v", err)
+	}
+	return body, nil
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// isAPIVersion returns whether the segment of a path is an API version e.g. 20160918
+func isAPIVersion(segment string) bool {
+	if len(segment) != 8 {
+		return false
+	}
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func stringValue(object jsonObject, key string) string {
+	value, _ := object[key].(string)
+	return value
+}
+
+func contentMD5(content []byte) string {
+	sum := md5.Sum(content)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
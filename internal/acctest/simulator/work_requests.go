@@ -0,0 +1,74 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package simulator
+
+import (
+	"net/http"
+	"strings"
+)
+
+// newWorkRequest stores a work request of an action on a resource and returns its id, the work requests complete as
+// soon as they are accepted since the simulator applies the changes synchronously
+func (s *Simulator) newWorkRequest(kind *resourceKind, resource jsonObject, action string) string {
+	operation := map[string]string{"CREATED": "CREATE", "UPDATED": "UPDATE", "DELETED": "DELETE"}[action]
+	timestamp := now()
+	workRequest := jsonObject{
+		"id":              s.newId("workrequest"),
+		"operationType":   operation + "_" + strings.ToUpper(kind.resourceType),
+		"status":          "SUCCEEDED",
+		"compartmentId":   resource["compartmentId"],
+		"percentComplete": 100,
+		"timeAccepted":    timestamp,
+		"timeStarted":     timestamp,
+		"timeFinished":    timestamp,
+		"resources": []jsonObject{{
+			"entityType": kind.resourceType,
+			"actionType": action,
+			"identifier": resource["id"],
+			"entityUri":  "/" + kind.collection + "/" + stringValue(resource, "id"),
+		}},
+	}
+	s.workRequests[stringValue(workRequest, "id")] = workRequest
+	return stringValue(workRequest, "id")
+}
+
+// serveWorkRequests serves the work requests of all the services:
+//
+//	GET /workRequests?compartmentId=<id>
+//	GET /workRequests/<id>
+//	GET /workRequests/<id>/errors
+//	GET /workRequests/<id>/logs
+func (s *Simulator) serveWorkRequests(w http.ResponseWriter, r *http.Request, segments []string) {
+	if r.Method != http.MethodGet || len(segments) > 2 {
+		writeError(w, errNotImplemented(r))
+		return
+	}
+	if len(segments) == 0 {
+		compartmentId := r.URL.Query().Get("compartmentId")
+		workRequests := []jsonObject{}
+		for _, workRequest := range s.workRequests {
+			if stringValue(workRequest, "compartmentId") == compartmentId {
+				workRequests = append(workRequests, workRequest)
+			}
+		}
+		writeJSON(w, http.StatusOK, workRequests)
+		return
+	}
+
+	workRequest, ok := s.workRequests[segments[0]]
+	if !ok {
+		writeError(w, errNotFound("work request %s not found", segments[0]))
+		return
+	}
+	if len(segments) == 1 {
+		writeJSON(w, http.StatusOK, workRequest)
+		return
+	}
+	switch segments[1] {
+	case "errors", "logs":
+		writeJSON(w, http.StatusOK, []jsonObject{})
+	default:
+		writeError(w, errNotImplemented(r))
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package acctest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	oci_identity "github.com/oracle/oci-go-sdk/v65/identity"
+
+	"github.com/oracle/terraform-provider-oci/internal/acctest/simulator"
+	tf_provider "github.com/oracle/terraform-provider-oci/internal/provider"
+	"github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+// simulatedResource is a resource of the provider created against the simulator
+type simulatedResource struct {
+	name     string
+	resource *schema.Resource
+	data     *schema.ResourceData
+}
+
+func createSimulatedResource(t *testing.T, clients interface{}, name string, config map[string]interface{}) *simulatedResource {
+	r := &simulatedResource{name: name, resource: tf_provider.ResourcesMap()[name]}
+	r.data = schema.TestResourceDataRaw(t, r.resource.Schema, config)
+	if err := r.resource.Create(r.data, clients); err != nil {
+		t.Fatalf("unexpected error creating %s: %v", name, err)
+	}
+	if r.data.Id() == "" {
+		t.Fatalf("expected %s to have an id", name)
+	}
+	if err := r.resource.Read(r.data, clients); err != nil {
+		t.Fatalf("unexpected error reading %s: %v", name, err)
+	}
+	return r
+}
+
+func (r *simulatedResource) delete(t *testing.T, clients interface{}) {
+	if err := r.resource.Delete(r.data, clients); err != nil {
+		t.Errorf("unexpected error deleting %s: %v", r.name, err)
+	}
+}
+
+func TestUnitSimulatorProviderResources(t *testing.T) {
+	s := simulator.NewSimulator()
+	defer s.Close()
+	s.Setenv(t)
+	// The other tests of the package mock the environment settings and the provider configuration
+	getEnvSettingWithBlankDefaultVar = utils.GetEnvSettingWithBlankDefault
+	getEnvSettingWithDefaultVar = utils.GetEnvSettingWithDefault
+	tfProviderConfigVar = tf_provider.ProviderConfig
+	clients := GetTestClients(nil)
+
+	compartment := createSimulatedResource(t, clients, "oci_identity_compartment", map[string]interface{}{
+		"compartment_id": s.TenancyId,
+		"name":           "simulator",
+		"description":    "simulator",
+		"enable_delete":  true,
+	})
+	compartmentId := compartment.data.Id()
+	policy := createSimulatedResource(t, clients, "oci_identity_policy", map[string]interface{}{
+		"compartment_id": compartmentId,
+		"name":           "simulator",
+		"description":    "simulator",
+		"statements":     []interface{}{"Allow group Administrators to manage all-resources in compartment simulator"},
+	})
+
+	vcn := createSimulatedResource(t, clients, "oci_core_vcn", map[string]interface{}{
+		"compartment_id": compartmentId,
+		"cidr_block":     "10.0.0.0/16",
+		"display_name":   "vcn",
+		"dns_label":      "vcn",
+	})
+	if domainName := vcn.data.Get("vcn_domain_name"); domainName != "vcn.oraclevcn.com" {
+		t.Errorf("expected the VCN domain name vcn.oraclevcn.com, got %v", domainName)
+	}
+	vcn.data.Set("display_name", "vcn2")
+	if err := vcn.resource.Update(vcn.data, clients); err != nil {
+		t.Fatalf("unexpected error updating the VCN: %v", err)
+	}
+	if displayName := vcn.data.Get("display_name"); displayName != "vcn2" {
+		t.Errorf("expected the display name vcn2, got %v", displayName)
+	}
+
+	subnet := createSimulatedResource(t, clients, "oci_core_subnet", map[string]interface{}{
+		"compartment_id": compartmentId,
+		"vcn_id":         vcn.data.Id(),
+		"cidr_block":     "10.0.1.0/24",
+		"dns_label":      "subnet",
+	})
+	instance := createSimulatedResource(t, clients, "oci_core_instance", map[string]interface{}{
+		"compartment_id":      compartmentId,
+		"availability_domain": "Sim:US-PHOENIX-1-AD-1",
+		"shape":               "VM.Standard2.1",
+		"create_vnic_details": []interface{}{map[string]interface{}{"subnet_id": subnet.data.Id()}},
+		"source_details":      []interface{}{map[string]interface{}{"source_type": "image", "source_id": "ocid1.image.oc1..simulator"}},
+	})
+	if state, privateIp := instance.data.Get("state"), instance.data.Get("private_ip"); state != "RUNNING" || privateIp != "10.0.1.2" {
+		t.Errorf("expected a running instance with the private IP 10.0.1.2, got %v and %v", state, privateIp)
+	}
+
+	bucket := createSimulatedResource(t, clients, "oci_objectstorage_bucket", map[string]interface{}{
+		"compartment_id": compartmentId,
+		"namespace":      s.Namespace,
+		"name":           "simulator",
+	})
+	object := createSimulatedResource(t, clients, "oci_objectstorage_object", map[string]interface{}{
+		"namespace": s.Namespace,
+		"bucket":    "simulator",
+		"object":    "simulator",
+		"content":   "simulator",
+	})
+
+	for _, r := range []*simulatedResource{object, bucket, instance, subnet, vcn, policy, compartment} {
+		r.delete(t, clients)
+	}
+	response, err := clients.IdentityClient().GetCompartment(context.Background(), oci_identity.GetCompartmentRequest{CompartmentId: &compartmentId})
+	if err != nil {
+		t.Fatalf("unexpected error getting the compartment: %v", err)
+	}
+	if response.LifecycleState != oci_identity.CompartmentLifecycleStateDeleted {
+		t.Errorf("expected the compartment to be deleted, got %v", response.LifecycleState)
+	}
+}
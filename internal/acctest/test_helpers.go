@@ -736,6 +736,7 @@ func GetTestClients(data *schema.ResourceData) *tf_client.OracleClients {
 	if err != nil {
 		panic(err)
 	}
+	tagTestRunResources()
 
 	// This is a test hook to support creating instances that have a maintenance reboot time set
 	// The test hook allows 'time_maintenance_reboot_due' field to be tested for instance datasources/resources
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/oracle/terraform-provider-oci/internal/tfresource"
 
@@ -25,6 +26,9 @@ import (
 	utils "github.com/oracle/terraform-provider-oci/internal/utils"
 )
 
+// WorkRequestClientName is the name of the work requests client in the CLIENT_HOST_OVERRIDES environment variable
+const WorkRequestClientName = "oci_work_requests.WorkRequestClient"
+
 var OracleClientRegistrationsVar *OracleClientRegistrations // This is a global registration for all oracle clients. This is invariant information about all clients regardless of region
 
 func RegisterOracleClient(name string, client *OracleClient) {
@@ -58,12 +62,74 @@ type OracleClients struct {
 	Configuration     map[string]string
 	SdkClientMap      map[string]interface{}
 	WorkRequestClient *oci_work_requests.WorkRequestClient
+
+	configProvider       oci_common.ConfigurationProvider
+	configureClient      ConfigureClient
+	regionalClients      map[string]*OracleClients
+	regionalClientsMutex sync.Mutex
+}
+
+func init() {
+	tfresource.ClientsForRegionVar = func(m interface{}, region string) (interface{}, error) {
+		clients, ok := m.(*OracleClients)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T for the provider clients", m)
+		}
+		return clients.ForRegion(region)
+	}
 }
 
 func (m *OracleClients) GetClient(name string) interface{} {
 	return m.SdkClientMap[name]
 }
 
+// ForRegion returns clients that reuse the configuration provider of these clients but talk to another region.
+// They are created the first time a region is asked for, and cached for the lifetime of the provider.
+func (m *OracleClients) ForRegion(region string) (*OracleClients, error) {
+	if region == "" || strings.EqualFold(region, m.Configuration["region"]) {
+		return m, nil
+	}
+	if m.configProvider == nil {
+		return nil, fmt.Errorf("cannot Create clients for region %s, the provider clients were not initialized", region)
+	}
+
+	m.regionalClientsMutex.Lock()
+	defer m.regionalClientsMutex.Unlock()
+	if clients, ok := m.regionalClients[region]; ok {
+		return clients, nil
+	}
+
+	configuration := make(map[string]string, len(m.Configuration))
+	for key, value := range m.Configuration {
+		configuration[key] = value
+	}
+	configuration["region"] = region
+	clients := &OracleClients{
+		SdkClientMap:  make(map[string]interface{}, len(m.SdkClientMap)),
+		Configuration: configuration,
+	}
+	if err := CreateSDKClients(clients, regionConfigurationProvider{ConfigurationProvider: m.configProvider, region: region}, m.configureClient); err != nil {
+		return nil, fmt.Errorf("cannot Create clients for region %s: %v", region, err)
+	}
+
+	if m.regionalClients == nil {
+		m.regionalClients = make(map[string]*OracleClients)
+	}
+	m.regionalClients[region] = clients
+	return clients, nil
+}
+
+// regionConfigurationProvider overrides the region of a configuration provider, everything else including
+// the credentials used to sign requests comes from the wrapped provider
+type regionConfigurationProvider struct {
+	oci_common.ConfigurationProvider
+	region string
+}
+
+func (p regionConfigurationProvider) Region() (string, error) {
+	return p.region, nil
+}
+
 // The following clients require special endpoint information that is only known at Terraform apply time; so they
 // Create duplicate clients reusing the same Configuration provider as the initialized client and adding the endpoint
 // here.
@@ -134,6 +200,9 @@ func CreateSDKClients(clients *OracleClients, configProvider oci_common.Configur
 		return fmt.Errorf("there are no clients to Create")
 	}
 
+	clients.configProvider = configProvider
+	clients.configureClient = configureClient
+
 	clientHostOverrides := getClientHostOverrides()
 	for serviceName, clientRegistration := range OracleClientRegistrationsVar.RegisteredClients {
 		if clientRegistration.InitClientFn != nil {
@@ -145,7 +214,7 @@ func CreateSDKClients(clients *OracleClients, configProvider oci_common.Configur
 			if !common.CheckForEnabledServices(utils.GetSDKServiceName(serviceName)) {
 				continue
 			}
-			clients.SdkClientMap[serviceName], err = clientRegistration.InitClientFn(configProvider, configureClient, serviceClientOverrides)
+			clients.SdkClientMap[serviceName], err = clientRegistration.InitClientFn(configProvider, configureRateLimitedClient(serviceName, configureClient), serviceClientOverrides)
 			if err != nil {
 				return err
 			}
@@ -162,6 +231,9 @@ func CreateSDKClients(clients *OracleClients, configProvider oci_common.Configur
 		if err != nil {
 			return err
 		}
+		if host, ok := clientHostOverrides[WorkRequestClientName]; ok {
+			workRequestClient.Host = host
+		}
 		clients.WorkRequestClient = &workRequestClient
 	}
 	return nil
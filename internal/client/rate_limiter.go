@@ -0,0 +1,195 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package client
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	oci_common "github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// RateLimitConfiguration holds the client side limits applied to the requests sent by a registered client
+type RateLimitConfiguration struct {
+	RequestsPerSecond     float64
+	Burst                 int
+	MaxConcurrentRequests int
+}
+
+// RateLimiter is a token bucket limiter combined with a cap on the number of in-flight requests.
+// It is shared by all the clients created for the same registered client name, including the regional ones.
+type RateLimiter struct {
+	mutex      sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	last       time.Time
+	pauseUntil time.Time
+	inFlight   chan struct{}
+}
+
+var (
+	rateLimiters      map[string]*RateLimiter
+	rateLimitersMutex sync.RWMutex
+)
+
+// SetRateLimitConfigurations replaces the rate limiters used by the clients with ones built from the given configurations,
+// keyed by registered client name, e.g. oci_identity.IdentityClient
+func SetRateLimitConfigurations(configurations map[string]RateLimitConfiguration) {
+	limiters := make(map[string]*RateLimiter, len(configurations))
+	for name, configuration := range configurations {
+		limiters[name] = NewRateLimiter(configuration)
+	}
+
+	rateLimitersMutex.Lock()
+	defer rateLimitersMutex.Unlock()
+	rateLimiters = limiters
+}
+
+func getRateLimiter(name string) *RateLimiter {
+	rateLimitersMutex.RLock()
+	defer rateLimitersMutex.RUnlock()
+	return rateLimiters[name]
+}
+
+func NewRateLimiter(configuration RateLimitConfiguration) *RateLimiter {
+	limiter := &RateLimiter{
+		rate:  configuration.RequestsPerSecond,
+		burst: float64(configuration.Burst),
+		last:  time.Now(),
+	}
+	if limiter.rate > 0 && limiter.burst < 1 {
+		limiter.burst = math.Max(1, math.Ceil(limiter.rate))
+	}
+	limiter.tokens = limiter.burst
+	if configuration.MaxConcurrentRequests > 0 {
+		limiter.inFlight = make(chan struct{}, configuration.MaxConcurrentRequests)
+	}
+	return limiter
+}
+
+// Acquire blocks until the request is allowed to be sent. The returned function must be called once the response is received.
+func (l *RateLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.inFlight != nil {
+			<-l.inFlight
+		}
+	}
+
+	for {
+		wait := l.reserve(time.Now())
+		if wait <= 0 {
+			return release, nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			release()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token from the bucket, or returns how long to wait before trying again
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if now.Before(l.pauseUntil) {
+		return l.pauseUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// PauseUntil holds back every request of the limiter until the given time, e.g. when the service asked to Retry-After
+func (l *RateLimiter) PauseUntil(until time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if until.After(l.pauseUntil) {
+		l.pauseUntil = until
+	}
+}
+
+type rateLimitedDispatcher struct {
+	dispatcher oci_common.HTTPRequestDispatcher
+	limiter    *RateLimiter
+	name       string
+}
+
+func (d rateLimitedDispatcher) Do(req *http.Request) (*http.Response, error) {
+	release, err := d.limiter.Acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	response, err := d.dispatcher.Do(req)
+	if response != nil {
+		if retryAfter, ok := getRetryAfter(response, time.Now()); ok {
+			log.Printf("[DEBUG] %s received status %d, holding back its requests for %s as requested by the Retry-After header", d.name, response.StatusCode, retryAfter)
+			d.limiter.PauseUntil(time.Now().Add(retryAfter))
+		}
+	}
+	return response, err
+}
+
+// getRetryAfter returns the delay asked by the Retry-After header of a throttled or unavailable response
+func getRetryAfter(response *http.Response, now time.Time) (time.Duration, bool) {
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	retryAfter := response.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		if seconds <= 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil && date.After(now) {
+		return date.Sub(now), true
+	}
+	return 0, false
+}
+
+// configureRateLimitedClient wraps the ConfigureClient hook of a registered client so that the requests it sends
+// go through the rate limiter configured for that client name, if any
+func configureRateLimitedClient(name string, configureClient ConfigureClient) ConfigureClient {
+	return func(client *oci_common.BaseClient) error {
+		if err := configureClient(client); err != nil {
+			return err
+		}
+		if limiter := getRateLimiter(name); limiter != nil && client.HTTPClient != nil {
+			client.HTTPClient = rateLimitedDispatcher{dispatcher: client.HTTPClient, limiter: limiter, name: name}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitRateLimiterReserve(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(RateLimitConfiguration{RequestsPerSecond: 2, Burst: 2})
+	limiter.last = now
+
+	assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(now))
+	assert.Equal(t, time.Duration(0), limiter.reserve(now.Add(500*time.Millisecond)))
+
+	limiter.PauseUntil(now.Add(3 * time.Second))
+	assert.Equal(t, 2*time.Second, limiter.reserve(now.Add(time.Second)))
+
+	unlimited := NewRateLimiter(RateLimitConfiguration{})
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, time.Duration(0), unlimited.reserve(now))
+	}
+}
+
+func TestUnitRateLimiterMaxConcurrentRequests(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfiguration{MaxConcurrentRequests: 1})
+
+	release, err := limiter.Acquire(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx)
+	assert.Error(t, err)
+
+	release()
+	release, err = limiter.Acquire(context.Background())
+	assert.NoError(t, err)
+	release()
+}
+
+func TestUnitGetRetryAfter(t *testing.T) {
+	now := time.Now()
+	type testFormat struct {
+		name       string
+		statusCode int
+		retryAfter string
+		ok         bool
+		expected   time.Duration
+	}
+	tests := []testFormat{
+		{"throttled with seconds", http.StatusTooManyRequests, "3", true, 3 * time.Second},
+		{"unavailable with seconds", http.StatusServiceUnavailable, "1", true, time.Second},
+		{"throttled with date", http.StatusTooManyRequests, now.Add(10 * time.Second).UTC().Format(http.TimeFormat), true, 0},
+		{"throttled without header", http.StatusTooManyRequests, "", false, 0},
+		{"throttled with invalid header", http.StatusTooManyRequests, "soon", false, 0},
+		{"not throttled", http.StatusOK, "3", false, 0},
+	}
+	for _, test := range tests {
+		t.Logf("Running %s", test.name)
+		response := &http.Response{StatusCode: test.statusCode, Header: http.Header{}}
+		if test.retryAfter != "" {
+			response.Header.Set("Retry-After", test.retryAfter)
+		}
+		retryAfter, ok := getRetryAfter(response, now)
+		assert.Equal(t, test.ok, ok)
+		if test.expected != 0 {
+			assert.Equal(t, test.expected, retryAfter)
+		} else if ok {
+			assert.True(t, retryAfter > 8*time.Second && retryAfter <= 10*time.Second)
+		}
+	}
+}
@@ -56,10 +56,35 @@ func (ociRes *OCIResource) GetHCLString(builder *strings.Builder, interpolationM
 		}
 	}
 
+	getHclStringFn := GetHclStringFromGenericMap
 	if ociRes.GetHclStringFn != nil {
-		return ociRes.GetHclStringFn(builder, ociRes, resourceInterpolationMap)
+		getHclStringFn = ociRes.GetHclStringFn
 	}
-	return GetHclStringFromGenericMap(builder, ociRes, resourceInterpolationMap)
+	if ociRes.ProviderAlias == "" {
+		return getHclStringFn(builder, ociRes, resourceInterpolationMap)
+	}
+
+	// Resources of the other regions set the provider of their region in the first line of the block
+	resourceBuilder := &strings.Builder{}
+	if err := getHclStringFn(resourceBuilder, ociRes, resourceInterpolationMap); err != nil {
+		return err
+	}
+	hclString := resourceBuilder.String()
+	if blockStart := strings.Index(hclString, "{\n"); blockStart >= 0 {
+		hclString = fmt.Sprintf("%sprovider = %s\n%s", hclString[:blockStart+2], GetProviderReference(ociRes.ProviderAlias), hclString[blockStart+2:])
+	}
+	builder.WriteString(hclString)
+	return nil
+}
+
+// GetProviderAlias returns the alias of the provider generated for a region e.g. us_ashburn_1 for us-ashburn-1
+func GetProviderAlias(region string) string {
+	return strings.ReplaceAll(region, "-", "_")
+}
+
+// GetProviderReference returns the value of the provider meta-argument for the provider with the given alias
+func GetProviderReference(alias string) string {
+	return TfHclVersionvar.GetReference(fmt.Sprintf("oci.%s", alias))
 }
 
 func (tr *TerraformResource) GetHclReferenceIdString() string {
@@ -69,6 +94,34 @@ func (tr *TerraformResource) GetHclReferenceIdString() string {
 	return TfHclVersionvar.GetDoubleExpHclString(tr.GetTerraformReference(), "id")
 }
 
+// GetImportBlockHclString returns the Terraform 1.5+ `import` block of the resource, or an empty string if the resource cannot be imported
+func (ociRes *OCIResource) GetImportBlockHclString() string {
+	return ociRes.GetImportBlockHclStringForAddress(ociRes.GetTerraformReference())
+}
+
+// GetImportBlockHclStringForAddress returns the `import` block of the resource to the given address, e.g. when the resource is in a module
+func (ociRes *OCIResource) GetImportBlockHclStringForAddress(address string) string {
+	if ociRes.TerraformTypeInfo != nil && ociRes.TerraformTypeInfo.IsDataSource {
+		return ""
+	}
+
+	resourceDefinition, exists := ResourcesMap[ociRes.TerraformClass]
+	if !exists || resourceDefinition.Importer == nil {
+		utils.Logf("[WARN] unable to generate import block for '%s' because import is not supported for '%s'", ociRes.GetTerraformReference(), ociRes.TerraformClass)
+		return ""
+	}
+
+	importId := ociRes.ImportId
+	if len(importId) == 0 {
+		importId = ociRes.Id
+	}
+
+	if ociRes.ProviderAlias != "" {
+		return fmt.Sprintf("import {\nto = %s\nid = %q\nprovider = %s\n}\n\n", address, escapeTFStrings(importId), GetProviderReference(ociRes.ProviderAlias))
+	}
+	return fmt.Sprintf("import {\nto = %s\nid = %q\n}\n\n", address, escapeTFStrings(importId))
+}
+
 func GetHCLStringFromMap(builder *strings.Builder, sourceAttributes map[string]interface{}, resourceSchema *schema.Resource, interpolationMap map[string]string, ociRes *OCIResource, attributePrefix string) error {
 	sortedKeys := make([]string, len(resourceSchema.Schema))
 	cnt := 0
@@ -84,7 +137,24 @@ func GetHCLStringFromMap(builder *strings.Builder, sourceAttributes map[string]i
 			continue
 		}
 
-		if attributeVal, exists := sourceAttributes[tfAttribute]; exists {
+		attributeVal, exists := sourceAttributes[tfAttribute]
+		if exists && tfSchema.Sensitive && attributeVal != nil {
+			if SensitiveAttributesExport == SensitiveAttributesOmit {
+				utils.Logf("[INFO] Sensitive TF attribute '%s' omitted\n", tfAttribute)
+				// the placeholder of a required attribute is written below so that the resource can still be planned
+				exists = false
+				if !tfSchema.Required {
+					builder.WriteString(fmt.Sprintf("#%s = <<Sensitive value omitted>>\n", tfAttribute))
+					continue
+				}
+			} else {
+				variableName := exportSensitiveAttributeAsVariable(ociRes, attributePrefix, tfAttribute)
+				builder.WriteString(fmt.Sprintf("%s = %s\n", tfAttribute, TfHclVersionvar.GetVarHclString(variableName)))
+				continue
+			}
+		}
+
+		if exists {
 			utils.Debugf("Writing attribute %s and value %s", tfAttribute, attributeVal)
 			switch v := attributeVal.(type) {
 			case InterpolationString:
@@ -267,6 +337,13 @@ func (args *ExportCommandArgs) FinalizeServices(ctx *ResourceDiscoveryContext) {
 		*/
 		if args.CompartmentId != nil && (*args.CompartmentId == "" || *args.CompartmentId == ctx.TenancyOcid) {
 			args.Services = append(args.Services, TenancyScopeServices...)
+		} else {
+			for _, compartment := range args.Compartments {
+				if compartment.CompartmentId != nil && *compartment.CompartmentId == ctx.TenancyOcid {
+					args.Services = append(args.Services, TenancyScopeServices...)
+					break
+				}
+			}
 		}
 	}
 
@@ -325,6 +402,93 @@ func (args *ExportCommandArgs) Validate() error {
 		return fmt.Errorf("[ERROR] invalid value for arument parallelism, specify a value >= 1")
 	}
 
+	if len(args.Compartments) > 0 {
+		if (args.CompartmentId != nil && *args.CompartmentId != "") || (args.CompartmentName != nil && *args.CompartmentName != "") {
+			return fmt.Errorf("[ERROR] compartments cannot be used with compartment_id or compartment_name, specify only one of them")
+		}
+		for _, compartment := range args.Compartments {
+			hasId := compartment.CompartmentId != nil && *compartment.CompartmentId != ""
+			hasName := compartment.CompartmentName != nil && *compartment.CompartmentName != ""
+			if hasId == hasName {
+				return fmt.Errorf("[ERROR] specify one of compartment_id or compartment_name for each of the compartments")
+			}
+		}
+	}
+
+	if len(args.Regions) > 0 {
+		regions := map[string]bool{}
+		for _, region := range args.Regions {
+			if region == "" || regions[region] {
+				return fmt.Errorf("[ERROR] invalid value for argument regions, specify each region once")
+			}
+			regions[region] = true
+		}
+	}
+
+	if len(args.Regions) > 1 || len(args.Compartments) > 1 {
+		if args.GenerateState {
+			return fmt.Errorf("[ERROR] generate_state is not supported when exporting more than one compartment or region, use generate_import_blocks to import the resources")
+		}
+		if len(args.IDs) > 0 {
+			return fmt.Errorf("[ERROR] ids cannot be used when exporting more than one compartment or region")
+		}
+	}
+	if len(args.Regions) > 1 && args.OutputLayout == OutputLayoutModules {
+		return fmt.Errorf("[ERROR] output_layout %s is not supported when exporting more than one region", OutputLayoutModules)
+	}
+
+	if args.BaselineState != nil && *args.BaselineState != "" {
+		if baselineState, err := os.Stat(*args.BaselineState); err != nil {
+			return fmt.Errorf("[ERROR] baseline_state does not exist: %s", err)
+		} else if baselineState.IsDir() {
+			return fmt.Errorf("[ERROR] baseline_state %s should be a state file", *args.BaselineState)
+		}
+	}
+
+	if args.ReportPath != nil && *args.ReportPath != "" {
+		if reportPath, err := os.Stat(*args.ReportPath); err == nil && reportPath.IsDir() {
+			return fmt.Errorf("[ERROR] report_path %s should be a file", *args.ReportPath)
+		}
+	}
+
+	switch args.OutputLayout {
+	case "", OutputLayoutFlat:
+	case OutputLayoutModules:
+		if args.GenerateState {
+			return fmt.Errorf("[ERROR] generate_state is not supported with output_layout %s, use generate_import_blocks to import the resources into the modules", OutputLayoutModules)
+		}
+		if args.TFVersion != nil && *args.TFVersion != nil && (*args.TFVersion).ToString() == string(TfVersion11) {
+			return fmt.Errorf("[ERROR] output_layout %s requires tf_version 0.12 syntax", OutputLayoutModules)
+		}
+	default:
+		return fmt.Errorf("[ERROR] invalid value for argument output_layout: %s, supported values: %s, %s", args.OutputLayout, OutputLayoutFlat, OutputLayoutModules)
+	}
+
+	if args.GenerateImportBlocks {
+		if args.GenerateState {
+			return fmt.Errorf("[ERROR] generate_state and generate_import_blocks cannot be used together, specify only one of them")
+		}
+		if args.TFVersion != nil && *args.TFVersion != nil && (*args.TFVersion).ToString() == string(TfVersion11) {
+			return fmt.Errorf("[ERROR] generate_import_blocks requires tf_version 0.12 syntax, import blocks are supported by Terraform v1.5 and above")
+		}
+	}
+
+	switch args.SensitiveAttributes {
+	case "":
+		SensitiveAttributesExport = SensitiveAttributesVariable
+	case SensitiveAttributesVariable, SensitiveAttributesOmit:
+		SensitiveAttributesExport = args.SensitiveAttributes
+	default:
+		return fmt.Errorf("[ERROR] invalid value for argument sensitive_attributes: %s, supported values: %s, %s", args.SensitiveAttributes, SensitiveAttributesVariable, SensitiveAttributesOmit)
+	}
+
+	ResourceNamingTemplate = nil
+	if args.NamingTemplate != "" {
+		if ResourceNamingTemplate, err = NewNamingTemplate(args.NamingTemplate); err != nil {
+			return err
+		}
+	}
+
 	// validate and extract variables_resource_level
 	if args.VarsExportResourceLevel != nil {
 		VarsExportForResourceLevel, err = extractVarsExportResourceLevel(args.VarsExportResourceLevel)
@@ -431,6 +595,26 @@ func (ctx *ResourceDiscoveryContext) PrintErrors() ([]string, []string) {
 	return notDiscoveredParentResources, notDiscoveredChildResources
 }
 
+// GetErrorReports returns the errors of the export and the child resources that were not discovered because of them
+func (ctx *ResourceDiscoveryContext) GetErrorReports() []ExportErrorReport {
+	result := []ExportErrorReport{}
+	for _, resourceDiscoveryError := range ctx.ErrorList.Errors {
+		errorReport := ExportErrorReport{
+			ResourceType:          resourceDiscoveryError.ResourceType,
+			ParentResource:        resourceDiscoveryError.ParentResource,
+			NotDiscoveredChildren: []string{},
+		}
+		if resourceDiscoveryError.Error != nil {
+			errorReport.Error = resourceDiscoveryError.Error.Error()
+		}
+		if resourceDiscoveryError.ResourceGraph != nil && !ctx.TargetSpecificResources {
+			getNotFoundChildren(resourceDiscoveryError.ResourceType, resourceDiscoveryError.ResourceGraph, &errorReport.NotDiscoveredChildren)
+		}
+		result = append(result, errorReport)
+	}
+	return result
+}
+
 func (h *TerraformResourceHints) DiscoversWithSingularDatasource() bool {
 	return h.DatasourceItemsAttr == ""
 }
@@ -1,8 +1,13 @@
 package commonexport
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestUnitCheckDuplicateResourceName(t *testing.T) {
@@ -38,3 +43,143 @@ func TestUnitCheckDuplicateResourceName(t *testing.T) {
 
 	})
 }
+
+func TestUnitGetImportBlockHclString(t *testing.T) {
+	ResourcesMap = map[string]*schema.Resource{
+		"oci_core_vcn":        {Importer: &schema.ResourceImporter{}},
+		"oci_core_not_import": {},
+	}
+	defer func() { ResourcesMap = nil }()
+
+	resource := &OCIResource{TerraformResource: TerraformResource{Id: "ocid1.vcn.oc1..aaaa", TerraformClass: "oci_core_vcn", TerraformName: "export_vcn"}}
+	expected := "import {\nto = oci_core_vcn.export_vcn\nid = \"ocid1.vcn.oc1..aaaa\"\n}\n\n"
+	if got := resource.GetImportBlockHclString(); got != expected {
+		t.Errorf("expected import block %q, got %q", expected, got)
+	}
+
+	resource.ImportId = "vcns/${id}"
+	expected = "import {\nto = oci_core_vcn.export_vcn\nid = \"vcns/$${id}\"\n}\n\n"
+	if got := resource.GetImportBlockHclString(); got != expected {
+		t.Errorf("expected import block %q, got %q", expected, got)
+	}
+
+	TfHclVersionvar = &TfHclVersion12{Value: TfVersion12}
+	resource.ProviderAlias = "us_phoenix_1"
+	expected = "import {\nto = oci_core_vcn.export_vcn\nid = \"vcns/$${id}\"\nprovider = oci.us_phoenix_1\n}\n\n"
+	if got := resource.GetImportBlockHclString(); got != expected {
+		t.Errorf("expected import block %q, got %q", expected, got)
+	}
+
+	notImportable := &OCIResource{TerraformResource: TerraformResource{Id: "ocid1", TerraformClass: "oci_core_not_import", TerraformName: "r"}}
+	if got := notImportable.GetImportBlockHclString(); got != "" {
+		t.Errorf("expected no import block, got %q", got)
+	}
+
+	dataSource := &OCIResource{TerraformResource: TerraformResource{Id: "ocid1", TerraformClass: "oci_core_vcn", TerraformName: "d", TerraformTypeInfo: &TerraformResourceHints{IsDataSource: true}}}
+	if got := dataSource.GetImportBlockHclString(); got != "" {
+		t.Errorf("expected no import block for data source, got %q", got)
+	}
+}
+
+func TestUnitValidateGenerateImportBlocks(t *testing.T) {
+	outputDir := t.TempDir()
+	var tfVersion12 TfHclVersion = &TfHclVersion12{Value: TfVersion12}
+	var tfVersion11 TfHclVersion = &TfHclVersion11{Value: TfVersion11}
+
+	args := &ExportCommandArgs{OutputDir: &outputDir, Parallelism: 1, GenerateImportBlocks: true, TFVersion: &tfVersion12}
+	if err := args.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	args.GenerateState = true
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error when both generate_state and generate_import_blocks are set")
+	}
+
+	args.GenerateState = false
+	args.TFVersion = &tfVersion11
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error for tf_version 0.11")
+	}
+}
+
+func TestUnitValidateOutputLayout(t *testing.T) {
+	outputDir := t.TempDir()
+	var tfVersion12 TfHclVersion = &TfHclVersion12{Value: TfVersion12}
+
+	args := &ExportCommandArgs{OutputDir: &outputDir, Parallelism: 1, OutputLayout: OutputLayoutModules, TFVersion: &tfVersion12}
+	if err := args.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	args.GenerateState = true
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error when generate_state is set with output_layout modules")
+	}
+
+	args.GenerateState = false
+	args.OutputLayout = "nested"
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error for an invalid output_layout")
+	}
+}
+
+func TestUnitValidateSensitiveAttributes(t *testing.T) {
+	outputDir := t.TempDir()
+	defer func() { SensitiveAttributesExport = "" }()
+
+	args := &ExportCommandArgs{OutputDir: &outputDir, Parallelism: 1}
+	if err := args.Validate(); err != nil || SensitiveAttributesExport != SensitiveAttributesVariable {
+		t.Errorf("expected sensitive attributes to be exported as variables by default, got %q, error: %v", SensitiveAttributesExport, err)
+	}
+
+	args.SensitiveAttributes = SensitiveAttributesOmit
+	if err := args.Validate(); err != nil || SensitiveAttributesExport != SensitiveAttributesOmit {
+		t.Errorf("expected sensitive attributes to be omitted, got %q, error: %v", SensitiveAttributesExport, err)
+	}
+
+	args.SensitiveAttributes = "plain"
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error for an invalid sensitive_attributes")
+	}
+}
+
+func TestUnitGetErrorReports(t *testing.T) {
+	resourceGraph := &TerraformResourceGraph{
+		"oci_core_vcn":    {{TerraformResourceHints: &TerraformResourceHints{ResourceClass: "oci_core_subnet"}}},
+		"oci_core_subnet": {{TerraformResourceHints: &TerraformResourceHints{ResourceClass: "oci_core_private_ip"}}},
+	}
+	ctx := &ResourceDiscoveryContext{}
+	ctx.AddErrorToList(&ResourceDiscoveryError{ResourceType: "oci_core_vcn", ParentResource: "export", Error: fmt.Errorf("list failed"), ResourceGraph: resourceGraph})
+	ctx.AddErrorToList(&ResourceDiscoveryError{Error: fmt.Errorf("ids not found")})
+
+	expected := []ExportErrorReport{
+		{ResourceType: "oci_core_vcn", ParentResource: "export", Error: "list failed", NotDiscoveredChildren: []string{"oci_core_subnet", "oci_core_private_ip"}},
+		{Error: "ids not found", NotDiscoveredChildren: []string{}},
+	}
+	if errorReports := ctx.GetErrorReports(); !reflect.DeepEqual(expected, errorReports) {
+		t.Errorf("expected %v, got %v", expected, errorReports)
+	}
+}
+
+func TestUnitGetHCLStringWithProviderAlias(t *testing.T) {
+	TfHclVersionvar = &TfHclVersion12{Value: TfVersion12}
+	resource := &OCIResource{
+		TerraformResource: TerraformResource{TerraformClass: "oci_core_vcn", TerraformName: "export_vcn"},
+		GetHclStringFn: func(builder *strings.Builder, ociRes *OCIResource, interpolationMap map[string]string) error {
+			builder.WriteString(fmt.Sprintf("resource %s %s {\ndisplay_name = \"vcn\"\n}\n\n", ociRes.TerraformClass, ociRes.TerraformName))
+			return nil
+		},
+		ProviderAlias: GetProviderAlias("us-phoenix-1"),
+	}
+
+	builder := &strings.Builder{}
+	builder.WriteString("## header\n")
+	if err := resource.GetHCLString(builder, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "## header\nresource oci_core_vcn export_vcn {\nprovider = oci.us_phoenix_1\ndisplay_name = \"vcn\"\n}\n\n"
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+}
@@ -58,3 +58,13 @@ func (tfversion *TfHclVersion12) GetSingleExpHclString(expString string) string
 func (tfversion *TfHclVersion12) GetDoubleExpHclString(expString1 string, expString2 string) string {
 	return fmt.Sprintf("%s.%s", expString1, expString2)
 }
+
+// GetTfHclVersion returns the syntax to generate for the value of the tf_version argument
+func GetTfHclVersion(tfVersion string) (TfHclVersion, error) {
+	if TfVersionEnum(tfVersion) == TfVersion11 {
+		return &TfHclVersion11{Value: TfVersionEnum(tfVersion)}, nil
+	} else if tfVersion == "" || TfVersionEnum(tfVersion) == TfVersion12 {
+		return &TfHclVersion12{Value: TfVersionEnum(tfVersion)}, nil
+	}
+	return nil, fmt.Errorf("[ERROR]: Invalid tf_version '%s', supported values: 0.11, 0.12", tfVersion)
+}
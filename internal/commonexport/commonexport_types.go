@@ -58,6 +58,7 @@ type OCIResource struct {
 	GetHclStringFn   func(*strings.Builder, *OCIResource, map[string]string) error
 	Parent           *OCIResource
 	IsErrorResource  bool
+	ProviderAlias    string // alias of the provider of the region of the resource, empty for the default provider
 }
 type TfHclVersion11 struct {
 	Value TfVersionEnum
@@ -98,6 +99,9 @@ type ResourceDiscoveryContext struct {
 	TimeTakenToDiscover          time.Duration
 	TimeTakenToGenerateState     time.Duration
 	TimeTakenForEntireExport     time.Duration
+	StepReports                  []ExportStepReport                  // summary of each step of the export, set when the export command returns
+	RegionClients                map[string]*tf_client.OracleClients // clients of each of the regions to export, set when exporting Regions
+	CompartmentPaths             map[string][]string                 // compartment paths used by the naming template, from CompartmentId to each compartment
 }
 type TerraformResource struct {
 	Id                         string
@@ -119,10 +123,18 @@ type TfHclVersion interface {
 type ExportCommandArgs struct {
 	CompartmentId                *string
 	CompartmentName              *string
+	Compartments                 []ExportCompartment // compartments to export instead of CompartmentId or CompartmentName, set from export_config
 	IDs                          []string
+	Regions                      []string // regions to export, the first region is the region of the default provider
 	Services                     []string
 	OutputDir                    *string
 	GenerateState                bool
+	GenerateImportBlocks         bool
+	OutputLayout                 string
+	SensitiveAttributes          string
+	NamingTemplate               string // template of the resource names e.g. {{abbrev}}_{{display_name}}
+	BaselineState                *string
+	ReportPath                   *string
 	TFVersion                    *TfHclVersion
 	RetryTimeout                 *string
 	ExcludeServices              []string
@@ -132,6 +144,42 @@ type ExportCommandArgs struct {
 	VarExportGlobalLevel         []string
 	Filters                      []ResourceFilter
 }
+
+type ExportCompartment struct {
+	CompartmentId   *string
+	CompartmentName *string
+	Filters         []ResourceFilter // filters applied to the resources of this compartment along with the filters of the export
+}
+
+// ExportReport is the machine-readable summary of an export written to report_path.
+// Fields may be added to the schema in later versions, existing fields are not renamed or removed.
+type ExportReport struct {
+	SchemaVersion                   string              `json:"schema_version"`
+	Status                          string              `json:"status"`
+	Error                           string              `json:"error,omitempty"`
+	CompartmentId                   string              `json:"compartment_id,omitempty"`
+	OutputPath                      string              `json:"output_path,omitempty"`
+	TotalResources                  int                 `json:"total_resources"`
+	TimeTakenToDiscoverSeconds      float64             `json:"time_taken_to_discover_seconds"`
+	TimeTakenToGenerateStateSeconds float64             `json:"time_taken_to_generate_state_seconds"`
+	TimeTakenForEntireExportSeconds float64             `json:"time_taken_for_entire_export_seconds"`
+	Steps                           []ExportStepReport  `json:"steps"`
+	Errors                          []ExportErrorReport `json:"errors"`
+	MissingAttributesPerResource    map[string][]string `json:"missing_attributes_per_resource"`
+}
+type ExportStepReport struct {
+	Name                            string  `json:"name"`
+	DiscoveredResources             int     `json:"discovered_resources"`
+	OmittedResources                int     `json:"omitted_resources"`
+	TimeTakenToDiscoverSeconds      float64 `json:"time_taken_to_discover_seconds"`
+	TimeTakenToGenerateStateSeconds float64 `json:"time_taken_to_generate_state_seconds"`
+}
+type ExportErrorReport struct {
+	ResourceType          string   `json:"resource_type"`
+	ParentResource        string   `json:"parent_resource"`
+	Error                 string   `json:"error"`
+	NotDiscoveredChildren []string `json:"not_discovered_children"` // child resource types not discovered because of the error
+}
 type ErrorList struct {
 	Errors []*ResourceDiscoveryError
 }
@@ -188,6 +236,19 @@ func (tfE ResourceDiscoveryCustomError) Error() error {
 
 type ErrorTypeEnum string
 
+const (
+	// Version of the schema of the report written to report_path
+	ExportReportSchemaVersion = "1"
+
+	// Layouts of the generated configuration
+	OutputLayoutFlat    = "flat"    // one file per service in output_path
+	OutputLayoutModules = "modules" // one module per compartment under output_path/modules, mirroring the compartment tree
+
+	// Ways to export the attributes that the schema marks as sensitive
+	SensitiveAttributesVariable = "variable" // sensitive variables without default values
+	SensitiveAttributesOmit     = "omit"     // not written to the configuration
+)
+
 var TfHclVersionvar TfHclVersion
 var GetHclStringFromGenericMap = func(builder *strings.Builder, ociRes *OCIResource, interpolationMap map[string]string) error {
 	resourceSchema := ResourcesMap[ociRes.TerraformClass]
@@ -245,6 +306,8 @@ var availabilityDomainResourceGraph TerraformResourceGraph = make(map[string][]T
 
 var VarsExportForResourceLevel map[string][]string // store resource type and attribute from customer input to be converted in var file for resource level
 var VarsExportForGlobalLevel []string              // store attributes list from customer input to be converted in var file for global level
+var SensitiveAttributesExport string               // how the sensitive attributes are exported, SensitiveAttributesVariable or SensitiveAttributesOmit
+var SensitiveVars map[string]bool                  // variables of the sensitive attributes, written with sensitive = true and without default value
 
 // Tags to filter resources
 const OkeTagValue = "oke"
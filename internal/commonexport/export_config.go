@@ -0,0 +1,203 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package commonexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"gopkg.in/yaml.v2"
+)
+
+// ExportConfig is the content of the export_config file. The keys are the names of the command line arguments,
+// comma-separated arguments and repeated filter arguments are lists.
+type ExportConfig struct {
+	CompartmentId           *string                   `json:"compartment_id" yaml:"compartment_id"`
+	CompartmentName         *string                   `json:"compartment_name" yaml:"compartment_name"`
+	Compartments            []ExportCompartmentConfig `json:"compartments" yaml:"compartments"`
+	Services                []string                  `json:"services" yaml:"services"`
+	ExcludeServices         []string                  `json:"exclude_services" yaml:"exclude_services"`
+	IDs                     []string                  `json:"ids" yaml:"ids"`
+	Regions                 []string                  `json:"regions" yaml:"regions"`
+	OutputPath              *string                   `json:"output_path" yaml:"output_path"`
+	GenerateState           *bool                     `json:"generate_state" yaml:"generate_state"`
+	GenerateImportBlocks    *bool                     `json:"generate_import_blocks" yaml:"generate_import_blocks"`
+	OutputLayout            *string                   `json:"output_layout" yaml:"output_layout"`
+	SensitiveAttributes     *string                   `json:"sensitive_attributes" yaml:"sensitive_attributes"`
+	NamingTemplate          *string                   `json:"naming_template" yaml:"naming_template"`
+	BaselineState           *string                   `json:"baseline_state" yaml:"baseline_state"`
+	ReportPath              *string                   `json:"report_path" yaml:"report_path"`
+	TFVersion               *string                   `json:"tf_version" yaml:"tf_version"`
+	RetryTimeout            *string                   `json:"retry_timeout" yaml:"retry_timeout"`
+	Parallelism             *int                      `json:"parallelism" yaml:"parallelism"`
+	IncludeRelatedResources *bool                     `json:"include_related_resources" yaml:"include_related_resources"`
+	VariablesResourceLevel  []string                  `json:"variables_resource_level" yaml:"variables_resource_level"`
+	VariablesGlobalLevel    []string                  `json:"variables_global_level" yaml:"variables_global_level"`
+	Filters                 []string                  `json:"filters" yaml:"filters"`
+}
+
+type ExportCompartmentConfig struct {
+	CompartmentId   *string  `json:"compartment_id" yaml:"compartment_id"`
+	CompartmentName *string  `json:"compartment_name" yaml:"compartment_name"`
+	Filters         []string `json:"filters" yaml:"filters"`
+}
+
+// LoadExportConfig reads the export_config file, the format is chosen from the extension of the file: .yaml, .yml, .json or .hcl
+func LoadExportConfig(path string) (*ExportConfig, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] unable to read export_config %s: %v", path, err)
+	}
+
+	config := &ExportConfig{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(content, config)
+	case ".json":
+		err = unmarshalExportConfigJson(content, config)
+	case ".hcl":
+		err = unmarshalExportConfigHcl(content, path, config)
+	default:
+		return nil, fmt.Errorf("[ERROR] unsupported export_config format %s, supported extensions: .yaml, .yml, .json, .hcl", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] unable to parse export_config %s: %v", path, err)
+	}
+	return config, nil
+}
+
+func unmarshalExportConfigJson(content []byte, config *ExportConfig) error {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// report the misspelled arguments instead of ignoring them
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(config)
+}
+
+// unmarshalExportConfigHcl reads the arguments from the attributes of an HCL file e.g. services = ["core", "identity"]
+func unmarshalExportConfigHcl(content []byte, path string, config *ExportConfig) error {
+	file, diags := hclsyntax.ParseConfig(content, path, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return diags
+	}
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	values := map[string]json.RawMessage{}
+	for name, attribute := range attributes {
+		value, diags := attribute.Expr.Value(nil)
+		if diags.HasErrors() {
+			return diags
+		}
+		valueJson, err := ctyjson.SimpleJSONValue{Value: value}.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		values[name] = valueJson
+	}
+
+	configJson, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return unmarshalExportConfigJson(configJson, config)
+}
+
+// ApplyTo sets the arguments of the export from the configuration. The arguments set on the command line, given by
+// their names in overrides, take precedence over the configuration.
+func (c *ExportConfig) ApplyTo(args *ExportCommandArgs, overrides map[string]bool) error {
+	setString := func(name string, value *string, arg **string) {
+		if value != nil && !overrides[name] {
+			*arg = value
+		}
+	}
+	setBool := func(name string, value *bool, arg *bool) {
+		if value != nil && !overrides[name] {
+			*arg = *value
+		}
+	}
+	setList := func(name string, value []string, arg *[]string) {
+		if value != nil && !overrides[name] {
+			*arg = value
+		}
+	}
+
+	setString("compartment_id", c.CompartmentId, &args.CompartmentId)
+	setString("compartment_name", c.CompartmentName, &args.CompartmentName)
+	setString("output_path", c.OutputPath, &args.OutputDir)
+	setString("baseline_state", c.BaselineState, &args.BaselineState)
+	setString("report_path", c.ReportPath, &args.ReportPath)
+	setString("retry_timeout", c.RetryTimeout, &args.RetryTimeout)
+	setBool("generate_state", c.GenerateState, &args.GenerateState)
+	setBool("generate_import_blocks", c.GenerateImportBlocks, &args.GenerateImportBlocks)
+	setBool("include_related_resources", c.IncludeRelatedResources, &args.IsExportWithRelatedResources)
+	setList("services", c.Services, &args.Services)
+	setList("exclude_services", c.ExcludeServices, &args.ExcludeServices)
+	setList("ids", c.IDs, &args.IDs)
+	setList("regions", c.Regions, &args.Regions)
+	setList("variables_resource_level", c.VariablesResourceLevel, &args.VarsExportResourceLevel)
+	setList("variables_global_level", c.VariablesGlobalLevel, &args.VarExportGlobalLevel)
+
+	if c.OutputLayout != nil && !overrides["output_layout"] {
+		args.OutputLayout = *c.OutputLayout
+	}
+	if c.SensitiveAttributes != nil && !overrides["sensitive_attributes"] {
+		args.SensitiveAttributes = *c.SensitiveAttributes
+	}
+	if c.NamingTemplate != nil && !overrides["naming_template"] {
+		args.NamingTemplate = *c.NamingTemplate
+	}
+	if c.Parallelism != nil && !overrides["parallelism"] {
+		args.Parallelism = *c.Parallelism
+	}
+	if c.TFVersion != nil && !overrides["tf_version"] {
+		tfVersion, err := GetTfHclVersion(*c.TFVersion)
+		if err != nil {
+			return err
+		}
+		args.TFVersion = &tfVersion
+	}
+
+	if c.Filters != nil && !overrides["filter"] {
+		filters, err := parseExportConfigFilters(c.Filters)
+		if err != nil {
+			return err
+		}
+		args.Filters = filters
+	}
+
+	if c.Compartments != nil {
+		args.Compartments = make([]ExportCompartment, 0, len(c.Compartments))
+		for _, compartment := range c.Compartments {
+			filters, err := parseExportConfigFilters(compartment.Filters)
+			if err != nil {
+				return err
+			}
+			args.Compartments = append(args.Compartments, ExportCompartment{
+				CompartmentId:   compartment.CompartmentId,
+				CompartmentName: compartment.CompartmentName,
+				Filters:         filters,
+			})
+		}
+	}
+	return nil
+}
+
+func parseExportConfigFilters(rawFilters []string) ([]ResourceFilter, error) {
+	var filters Filter
+	for _, rawFilter := range rawFilters {
+		if err := filters.Set(rawFilter); err != nil {
+			return nil, err
+		}
+	}
+	return filters, nil
+}
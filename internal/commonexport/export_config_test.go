@@ -0,0 +1,174 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package commonexport
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var exportConfigTestFiles = map[string]string{
+	"export.yaml": `
+output_path: /tmp/export
+services: [core, identity]
+parallelism: 4
+generate_import_blocks: true
+tf_version: "0.11"
+filters:
+  - Type=oci_core_vcn,oci_core_subnet
+compartments:
+  - compartment_id: ocid1.compartment.a
+    filters:
+      - Type!=oci_core_subnet
+`,
+	"export.json": `{
+  "output_path": "/tmp/export",
+  "services": ["core", "identity"],
+  "parallelism": 4,
+  "generate_import_blocks": true,
+  "tf_version": "0.11",
+  "filters": ["Type=oci_core_vcn,oci_core_subnet"],
+  "compartments": [{"compartment_id": "ocid1.compartment.a", "filters": ["Type!=oci_core_subnet"]}]
+}`,
+	"export.hcl": `
+output_path            = "/tmp/export"
+services               = ["core", "identity"]
+parallelism            = 4
+generate_import_blocks = true
+tf_version             = "0.11"
+filters                = ["Type=oci_core_vcn,oci_core_subnet"]
+compartments = [
+  {
+    compartment_id = "ocid1.compartment.a"
+    filters        = ["Type!=oci_core_subnet"]
+  },
+]
+`,
+}
+
+func writeExportConfigTestFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("unable to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestUnitLoadExportConfig(t *testing.T) {
+	for name, content := range exportConfigTestFiles {
+		t.Run(name, func(t *testing.T) {
+			config, err := LoadExportConfig(writeExportConfigTestFile(t, name, content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			outputDir := "/tmp/cli"
+			args := &ExportCommandArgs{OutputDir: &outputDir, Parallelism: 1}
+			if err := config.ApplyTo(args, map[string]bool{"output_path": true}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if *args.OutputDir != "/tmp/cli" {
+				t.Errorf("expected output_path set on the command line to take precedence, got %s", *args.OutputDir)
+			}
+			if !reflect.DeepEqual(args.Services, []string{"core", "identity"}) {
+				t.Errorf("unexpected services: %v", args.Services)
+			}
+			if args.Parallelism != 4 || !args.GenerateImportBlocks {
+				t.Errorf("unexpected parallelism %d or generate_import_blocks %v", args.Parallelism, args.GenerateImportBlocks)
+			}
+			if (*args.TFVersion).ToString() != string(TfVersion11) {
+				t.Errorf("unexpected tf_version: %s", (*args.TFVersion).ToString())
+			}
+			expectedFilters := []ResourceFilter{&ResourceTypeFilter{ResourceType: map[string]bool{"oci_core_vcn": true, "oci_core_subnet": true}, ResourceTypeOperator: INCLUDE}}
+			if !reflect.DeepEqual(args.Filters, expectedFilters) {
+				t.Errorf("unexpected filters: %v", args.Filters)
+			}
+			if len(args.Compartments) != 1 || *args.Compartments[0].CompartmentId != "ocid1.compartment.a" || len(args.Compartments[0].Filters) != 1 {
+				t.Errorf("unexpected compartments: %v", args.Compartments)
+			}
+		})
+	}
+}
+
+func TestUnitLoadExportConfigErrors(t *testing.T) {
+	invalidConfigs := map[string]string{
+		"unknown.yaml":    "output_pth: /tmp/export\n",
+		"unknown.json":    `{"output_pth": "/tmp/export"}`,
+		"invalid.hcl":     `output_path = var.output_path`,
+		"unsupported.txt": "output_path=/tmp/export",
+	}
+	for name, content := range invalidConfigs {
+		if _, err := LoadExportConfig(writeExportConfigTestFile(t, name, content)); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+
+	config, err := LoadExportConfig(writeExportConfigTestFile(t, "filter.yaml", "filters: [Type]\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := config.ApplyTo(&ExportCommandArgs{}, nil); err == nil {
+		t.Errorf("expected error for an invalid filter")
+	}
+}
+
+func TestUnitValidateCompartments(t *testing.T) {
+	outputDir := t.TempDir()
+	compartmentA, compartmentB, empty := "ocid1.compartment.a", "ocid1.compartment.b", ""
+
+	args := &ExportCommandArgs{OutputDir: &outputDir, Parallelism: 1, CompartmentId: &empty, Compartments: []ExportCompartment{{CompartmentId: &compartmentA}}}
+	if err := args.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	args.CompartmentId = &compartmentB
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error when compartments is set with compartment_id")
+	}
+
+	args.CompartmentId = &empty
+	args.Compartments = []ExportCompartment{{CompartmentId: &compartmentA, CompartmentName: &compartmentB}}
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error when a compartment has both compartment_id and compartment_name")
+	}
+
+	args.Compartments = []ExportCompartment{{CompartmentId: &compartmentA}, {CompartmentId: &compartmentB}}
+	if err := args.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	args.GenerateState = true
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error when generate_state is set with more than one compartment")
+	}
+}
+
+func TestUnitValidateRegions(t *testing.T) {
+	outputDir := t.TempDir()
+
+	args := &ExportCommandArgs{OutputDir: &outputDir, Parallelism: 1, Regions: []string{"us-ashburn-1", "us-phoenix-1"}}
+	if err := args.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	args.IDs = []string{"oci_core_vcn:ocid1.vcn"}
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error when ids is set with more than one region")
+	}
+
+	args.IDs = nil
+	args.OutputLayout = OutputLayoutModules
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error when output_layout modules is set with more than one region")
+	}
+
+	args.OutputLayout = OutputLayoutFlat
+	args.Regions = []string{"us-ashburn-1", "us-ashburn-1"}
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error for a repeated region")
+	}
+}
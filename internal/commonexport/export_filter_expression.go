@@ -0,0 +1,258 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package commonexport
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+)
+
+var (
+	// regex to match attribute filters of an expression, the value may be quoted to include spaces and parentheses
+	expressionFieldValueFilterPattern, _ = regexp.Compile("^AttrName=([Aa-zZ0-9-_.]+);Value(=|!=|~=|>|<)(.+)$")
+	// regex to match tag filters of an expression
+	expressionTagFilterPattern, _ = regexp.Compile("^(HasFreeformTag|HasDefinedTag)=([^;]+)(;Value=(.+))?$")
+)
+
+type filterTokenKind int
+
+const (
+	filterTokenPredicate filterTokenKind = iota
+	filterTokenOpenParenthesis
+	filterTokenCloseParenthesis
+	filterTokenAnd
+	filterTokenOr
+	filterTokenNot
+)
+
+type filterToken struct {
+	kind  filterTokenKind
+	value string
+}
+
+type filterExpressionParser struct {
+	tokens   []filterToken
+	position int
+}
+
+// parseFilterExpression parses filters combined with AND, OR, NOT and parentheses, NOT binds tighter than AND and AND binds tighter than OR
+// Type=oci_core_instance AND (AttrName=freeform_tags.env;Value=prod OR AttrName=time_created;Value>2024-01-01) AND NOT AttrName=shape;Value~=VM\.Standard2\..*
+// HasFreeformTag=env
+// HasDefinedTag=example-namespace.example-key
+// HasDefinedTag=example-namespace.example-key;Value=example-value
+// AttrName=display_name;Value~="web (prod|test)"
+func parseFilterExpression(rawFilter string) (ResourceFilter, error) {
+	tokens, err := tokenizeFilterExpression(rawFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+
+	parser := &filterExpressionParser{tokens: tokens}
+	filter, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.position < len(parser.tokens) {
+		return nil, fmt.Errorf("unexpected %s", parser.tokens[parser.position].value)
+	}
+	return filter, nil
+}
+
+// tokenizeFilterExpression splits the expression into parentheses, operators and filters. The words of a filter that are
+// not operators are kept together so that values with spaces are supported e.g. AttrName=display_name;Value=my vcn
+func tokenizeFilterExpression(rawFilter string) ([]filterToken, error) {
+	var tokens []filterToken
+	var predicate []string
+	endPredicate := func() {
+		if len(predicate) > 0 {
+			tokens = append(tokens, filterToken{kind: filterTokenPredicate, value: strings.Join(predicate, " ")})
+			predicate = nil
+		}
+	}
+
+	runes := []rune(rawFilter)
+	for i := 0; i < len(runes); {
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+		case runes[i] == '(' && len(predicate) == 0:
+			tokens = append(tokens, filterToken{kind: filterTokenOpenParenthesis, value: "("})
+			i++
+		case runes[i] == ')':
+			endPredicate()
+			tokens = append(tokens, filterToken{kind: filterTokenCloseParenthesis, value: ")"})
+			i++
+		default:
+			// read a word up to a space or a closing parenthesis outside of quotes
+			start, inQuotes := i, false
+			for ; i < len(runes); i++ {
+				if runes[i] == '"' {
+					inQuotes = !inQuotes
+				} else if !inQuotes && (unicode.IsSpace(runes[i]) || runes[i] == ')') {
+					break
+				}
+			}
+			if inQuotes {
+				return nil, fmt.Errorf("unterminated quote in %s", string(runes[start:]))
+			}
+
+			word := string(runes[start:i])
+			switch word {
+			case globalvar.AndOperator:
+				endPredicate()
+				tokens = append(tokens, filterToken{kind: filterTokenAnd, value: word})
+			case globalvar.OrOperator:
+				endPredicate()
+				tokens = append(tokens, filterToken{kind: filterTokenOr, value: word})
+			case globalvar.NotOperator:
+				endPredicate()
+				tokens = append(tokens, filterToken{kind: filterTokenNot, value: word})
+			default:
+				predicate = append(predicate, word)
+			}
+		}
+	}
+	endPredicate()
+	return tokens, nil
+}
+
+func (p *filterExpressionParser) next(kind filterTokenKind) bool {
+	if p.position < len(p.tokens) && p.tokens[p.position].kind == kind {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *filterExpressionParser) parseOr() (ResourceFilter, error) {
+	filter, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	filters := []ResourceFilter{filter}
+	for p.next(filterTokenOr) {
+		if filter, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return &OrFilter{Filters: filters}, nil
+}
+
+func (p *filterExpressionParser) parseAnd() (ResourceFilter, error) {
+	filter, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	filters := []ResourceFilter{filter}
+	for p.next(filterTokenAnd) {
+		if filter, err = p.parseNot(); err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return &AndFilter{Filters: filters}, nil
+}
+
+func (p *filterExpressionParser) parseNot() (ResourceFilter, error) {
+	if p.next(filterTokenNot) {
+		filter, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotFilter{NegatedFilter: filter}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterExpressionParser) parsePrimary() (ResourceFilter, error) {
+	if p.next(filterTokenOpenParenthesis) {
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.next(filterTokenCloseParenthesis) {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return filter, nil
+	}
+
+	if p.position >= len(p.tokens) {
+		return nil, fmt.Errorf("missing filter at the end of the expression")
+	}
+	token := p.tokens[p.position]
+	if token.kind != filterTokenPredicate {
+		return nil, fmt.Errorf("expected a filter before %s", token.value)
+	}
+	p.position++
+	return parseFilterPredicate(token.value)
+}
+
+// parseFilterPredicate parses a filter of an expression
+func parseFilterPredicate(predicate string) (ResourceFilter, error) {
+	if resourceTypeFilterPattern.MatchString(predicate) {
+		var filters Filter
+		return filters.ParseFilter(predicate)
+	}
+
+	if groups := expressionTagFilterPattern.FindStringSubmatch(predicate); groups != nil {
+		tagFilter := &TagFilter{
+			IsDefinedTag: groups[1] == globalvar.HasDefinedTag,
+			Key:          unquoteFilterValue(groups[2]),
+		}
+		if groups[3] != "" {
+			if !tagFilter.IsDefinedTag {
+				return nil, fmt.Errorf("%s does not support a value, use AttrName=freeform_tags.%s;Value=%s", globalvar.HasFreeformTag, tagFilter.Key, groups[4])
+			}
+			value := unquoteFilterValue(groups[4])
+			tagFilter.Value = &value
+		}
+		return tagFilter, nil
+	}
+
+	if groups := expressionFieldValueFilterPattern.FindStringSubmatch(predicate); groups != nil {
+		filterOperator, err := getFilterOperator(groups[2])
+		if err != nil {
+			return nil, err
+		}
+
+		value := unquoteFilterValue(groups[3])
+		values := []string{value}
+		switch filterOperator {
+		case INCLUDE, EXCLUDE:
+			values = convertArrStringToSlice(value, ",")
+		case MATCH:
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, fmt.Errorf("invalid regular expression %s: %v", value, err)
+			}
+		}
+
+		return &FieldValueFilter{
+			FieldPath:            groups[1],
+			ResourceTypeOperator: filterOperator,
+			Values:               values,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported filter %s", predicate)
+}
+
+func unquoteFilterValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
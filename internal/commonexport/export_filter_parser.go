@@ -57,6 +57,7 @@ func (f *Filter) Set(value string) error {
 // if one type, one attribute and array values is passed, it will be one filter
 // AttrName will not accept a list of names
 // Type will accept one value when used with AttrName and values
+// The filters can also be combined in an expression with AND, OR, NOT and parentheses, see parseFilterExpression
 func (f *Filter) ParseFilter(rawFilter string) (ResourceFilter, error) {
 	if resourceTypeFilterPattern.MatchString(rawFilter) {
 		// matches resource type filter
@@ -102,7 +103,13 @@ func (f *Filter) ParseFilter(rawFilter string) (ResourceFilter, error) {
 			Values:               fieldValues,
 		}, nil
 	}
-	return nil, fmt.Errorf("invalid value %s provided. Unable to parse filter. Please refer to filter documentation", rawFilter)
+
+	// the other filters are expressions combining the filters with AND, OR and NOT
+	resourceFilter, err := parseFilterExpression(rawFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %s provided. Unable to parse filter: %v. Please refer to filter documentation", rawFilter, err)
+	}
+	return resourceFilter, nil
 }
 
 // convert a delimited string of values into a slice
@@ -120,6 +127,12 @@ func getFilterOperator(input string) (FilterOperator, error) {
 		return INCLUDE, nil
 	} else if input == globalvar.NotEqualToOperator {
 		return EXCLUDE, nil
+	} else if input == globalvar.RegexOperator {
+		return MATCH, nil
+	} else if input == globalvar.GreaterThanOperator {
+		return GREATER_THAN, nil
+	} else if input == globalvar.LessThanOperator {
+		return LESS_THAN, nil
 	}
 
 	// default to include
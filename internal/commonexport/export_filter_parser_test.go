@@ -131,3 +131,77 @@ func TestUnitParseFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitParseFilterExpression(t *testing.T) {
+	var filterFlag Filter
+	value := "example-value"
+	tests := []struct {
+		testName     string
+		filterString string
+		expected     ResourceFilter
+	}{
+		{
+			"OrFilter",
+			"Type=oci_core_vcn OR HasFreeformTag=env",
+			&OrFilter{Filters: []ResourceFilter{
+				&ResourceTypeFilter{ResourceType: map[string]bool{"oci_core_vcn": true}, ResourceTypeOperator: INCLUDE},
+				&TagFilter{Key: "env"},
+			}},
+		},
+		{
+			"AndBindsTighterThanOr",
+			"HasFreeformTag=a OR HasFreeformTag=b AND NOT HasDefinedTag=example-namespace.example-key;Value=example-value",
+			&OrFilter{Filters: []ResourceFilter{
+				&TagFilter{Key: "a"},
+				&AndFilter{Filters: []ResourceFilter{
+					&TagFilter{Key: "b"},
+					&NotFilter{NegatedFilter: &TagFilter{IsDefinedTag: true, Key: "example-namespace.example-key", Value: &value}},
+				}},
+			}},
+		},
+		{
+			"Parentheses",
+			"(AttrName=time_created;Value>2024-01-01 OR AttrName=size_in_gbs;Value<100) AND AttrName=display_name;Value=my vcn, other vcn",
+			&AndFilter{Filters: []ResourceFilter{
+				&OrFilter{Filters: []ResourceFilter{
+					&FieldValueFilter{FieldPath: "time_created", ResourceTypeOperator: GREATER_THAN, Values: []string{"2024-01-01"}},
+					&FieldValueFilter{FieldPath: "size_in_gbs", ResourceTypeOperator: LESS_THAN, Values: []string{"100"}},
+				}},
+				&FieldValueFilter{FieldPath: "display_name", ResourceTypeOperator: INCLUDE, Values: []string{"my vcn", "other vcn"}},
+			}},
+		},
+		{
+			"QuotedRegex",
+			`AttrName=display_name;Value~="web (prod|test)"`,
+			&FieldValueFilter{FieldPath: "display_name", ResourceTypeOperator: MATCH, Values: []string{"web (prod|test)"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ans, err := filterFlag.ParseFilter(tt.filterString)
+			if err != nil {
+				t.Fatalf("unexpected error occurred in parsing filter %+v", err)
+			}
+			if !reflect.DeepEqual(ans, tt.expected) {
+				t.Errorf("got %+v, want %+v", ans, tt.expected)
+			}
+		})
+	}
+
+	invalidFilters := []string{
+		"Type=oci_core_vcn OR",
+		"(Type=oci_core_vcn",
+		"Type=oci_core_vcn)",
+		"Type=oci_core_vcn Type=oci_core_subnet",
+		"AND Type=oci_core_vcn",
+		"AttrName=shape;Value~=VM.Standard2.(",
+		`Type=oci_core_vcn AND AttrName=display_name;Value="web`,
+		"HasFreeformTag=env;Value=prod",
+	}
+	for _, invalidFilter := range invalidFilters {
+		if _, err := filterFlag.ParseFilter(invalidFilter); err == nil {
+			t.Errorf("expected error for filter %s", invalidFilter)
+		}
+	}
+}
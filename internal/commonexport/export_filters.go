@@ -3,13 +3,32 @@
 
 package commonexport
 
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type FilterOperator string
 
 const (
-	INCLUDE FilterOperator = "="
-	EXCLUDE FilterOperator = "!="
+	INCLUDE      FilterOperator = "="
+	EXCLUDE      FilterOperator = "!="
+	MATCH        FilterOperator = "~="
+	GREATER_THAN FilterOperator = ">"
+	LESS_THAN    FilterOperator = "<"
 )
 
+// layouts of the times compared by the GREATER_THAN and LESS_THAN operators e.g. time_created
+var filterTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
 // Type=oci_core_vcn
 // Type=oci_core_vcn, oci_core_instance
 // Type!=oci_core_vcn
@@ -49,6 +68,27 @@ type ResourceFieldValueFilter struct {
 	Values               []string
 }
 
+// AndFilter, OrFilter and NotFilter combine the filters of a filter expression
+// e.g. Type=oci_core_instance AND (HasFreeformTag=env OR NOT AttrName=shape;Value~=VM\.Standard2\..*)
+type AndFilter struct {
+	Filters []ResourceFilter
+}
+
+type OrFilter struct {
+	Filters []ResourceFilter
+}
+
+type NotFilter struct {
+	NegatedFilter ResourceFilter
+}
+
+// TagFilter checks the freeform or defined tag of the resource, Value is only used for defined tags
+type TagFilter struct {
+	IsDefinedTag bool
+	Key          string
+	Value        *string
+}
+
 // filter method returns true if the resource satisfies filter criteria with INCLUDE operator
 // filter method returns true if the resource doesn't satisfy filter criteria with EXCLUDE operator
 // If filter is not applicable, then also filter returns true
@@ -86,6 +126,23 @@ func (fv *FieldValueFilter) Filter(resource *OCIResource) bool {
 	}
 
 	switch fv.ResourceTypeOperator {
+	case MATCH:
+		for _, val := range vals {
+			for _, pattern := range fv.Values {
+				if matched, _ := regexp.MatchString(fmt.Sprintf("^(?:%s)$", pattern), fmt.Sprint(val)); matched {
+					return true
+				}
+			}
+		}
+	case GREATER_THAN, LESS_THAN:
+		for _, val := range vals {
+			for _, limit := range fv.Values {
+				comparison := compareFilterValues(fmt.Sprint(val), limit)
+				if (fv.ResourceTypeOperator == GREATER_THAN && comparison > 0) || (fv.ResourceTypeOperator == LESS_THAN && comparison < 0) {
+					return true
+				}
+			}
+		}
 	case EXCLUDE:
 		for _, val := range vals {
 			for _, unacceptableValue := range fv.Values {
@@ -112,3 +169,75 @@ func (rfv *ResourceFieldValueFilter) Filter(resource *OCIResource) bool {
 	// TODO:implement this filter
 	return false
 }
+
+func (af *AndFilter) Filter(resource *OCIResource) bool {
+	for _, filter := range af.Filters {
+		if !filter.Filter(resource) {
+			return false
+		}
+	}
+	return true
+}
+
+func (of *OrFilter) Filter(resource *OCIResource) bool {
+	for _, filter := range of.Filters {
+		if filter.Filter(resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func (nf *NotFilter) Filter(resource *OCIResource) bool {
+	if resource == nil {
+		return false
+	}
+	return !nf.NegatedFilter.Filter(resource)
+}
+
+// filter method returns true if the resource has the tag, and the defined tag has the value when a value is given
+func (tf *TagFilter) Filter(resource *OCIResource) bool {
+	if resource == nil {
+		return false
+	}
+
+	if !tf.IsDefinedTag {
+		return resource.HasFreeformTag(tf.Key)
+	}
+	if tf.Value != nil {
+		return resource.HasDefinedTag(tf.Key, *tf.Value)
+	}
+	definedTags, _ := resource.SourceAttributes["defined_tags"].(map[string]interface{})
+	_, hasDefinedTag := definedTags[tf.Key]
+	return hasDefinedTag
+}
+
+// compareFilterValues compares the values as numbers or times when both of them can be parsed, or else as strings
+func compareFilterValues(value string, limit string) int {
+	if valueNumber, err := strconv.ParseFloat(value, 64); err == nil {
+		if limitNumber, err := strconv.ParseFloat(limit, 64); err == nil {
+			switch {
+			case valueNumber < limitNumber:
+				return -1
+			case valueNumber > limitNumber:
+				return 1
+			}
+			return 0
+		}
+	}
+	if valueTime, ok := parseFilterTime(value); ok {
+		if limitTime, ok := parseFilterTime(limit); ok {
+			return valueTime.Compare(limitTime)
+		}
+	}
+	return strings.Compare(value, limit)
+}
+
+func parseFilterTime(value string) (time.Time, bool) {
+	for _, layout := range filterTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -678,3 +678,120 @@ func TestUnitFieldValueFilterExclude(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitFilterExpression(t *testing.T) {
+	var filterFlag Filter
+	// all instances with tag env=prod or created after a date, excluding shape VM.Standard2.*
+	resourceFilter, err := filterFlag.ParseFilter(`Type=oci_core_instance AND (AttrName=freeform_tags.env;Value=prod OR AttrName=time_created;Value>2024-01-01) AND NOT AttrName=shape;Value~=VM\.Standard2\..*`)
+	if err != nil {
+		t.Fatalf("unexpected error occurred in parsing filter %+v", err)
+	}
+
+	newInstance := func(class string, attributes map[string]interface{}) *OCIResource {
+		return &OCIResource{
+			CompartmentId:     resourceDiscoveryTestCompartmentOcid,
+			TerraformResource: TerraformResource{Id: "ocid1.a.b.c", TerraformClass: class, TerraformName: "res1"},
+			SourceAttributes:  attributes,
+		}
+	}
+	tests := []struct {
+		testName string
+		resource *OCIResource
+		expected bool
+	}{
+		{
+			"ProdInstance",
+			newInstance("oci_core_instance", map[string]interface{}{"freeform_tags": map[string]interface{}{"env": "prod"}, "time_created": "2023-05-01 10:00:00 +0000 UTC", "shape": "VM.Standard.E4.Flex"}),
+			true,
+		},
+		{
+			"NewInstance",
+			newInstance("oci_core_instance", map[string]interface{}{"time_created": "2024-03-01 10:00:00.123 +0000 UTC", "shape": "VM.Standard.E4.Flex"}),
+			true,
+		},
+		{
+			"OldInstance",
+			newInstance("oci_core_instance", map[string]interface{}{"freeform_tags": map[string]interface{}{"env": "dev"}, "time_created": "2023-05-01 10:00:00 +0000 UTC", "shape": "VM.Standard.E4.Flex"}),
+			false,
+		},
+		{
+			"ExcludedShape",
+			newInstance("oci_core_instance", map[string]interface{}{"freeform_tags": map[string]interface{}{"env": "prod"}, "shape": "VM.Standard2.1"}),
+			false,
+		},
+		{
+			"OtherType",
+			newInstance("oci_core_vcn", map[string]interface{}{"freeform_tags": map[string]interface{}{"env": "prod"}}),
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if ans := resourceFilter.Filter(tt.resource); ans != tt.expected {
+				t.Errorf("got %+v, want %+v", ans, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnitFieldValueFilterComparison(t *testing.T) {
+	resource := &OCIResource{
+		SourceAttributes: map[string]interface{}{
+			"size_in_gbs": 50,
+			"shape":       "VM.Standard2.1",
+		},
+	}
+
+	tests := []struct {
+		testName string
+		filter   *FieldValueFilter
+		expected bool
+	}{
+		{"GreaterThanNumber", &FieldValueFilter{FieldPath: "size_in_gbs", ResourceTypeOperator: GREATER_THAN, Values: []string{"9"}}, true},
+		{"LessThanNumber", &FieldValueFilter{FieldPath: "size_in_gbs", ResourceTypeOperator: LESS_THAN, Values: []string{"9"}}, false},
+		{"EqualNumber", &FieldValueFilter{FieldPath: "size_in_gbs", ResourceTypeOperator: INCLUDE, Values: []string{"50"}}, true},
+		{"MatchRegex", &FieldValueFilter{FieldPath: "shape", ResourceTypeOperator: MATCH, Values: []string{`VM\.Standard2\..*`}}, true},
+		{"MatchWholeValue", &FieldValueFilter{FieldPath: "shape", ResourceTypeOperator: MATCH, Values: []string{`Standard2`}}, false},
+		{"MissingField", &FieldValueFilter{FieldPath: "time_created", ResourceTypeOperator: GREATER_THAN, Values: []string{"2024-01-01"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if ans := tt.filter.Filter(resource); ans != tt.expected {
+				t.Errorf("got %+v, want %+v", ans, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnitTagFilter(t *testing.T) {
+	resource := &OCIResource{
+		SourceAttributes: map[string]interface{}{
+			"freeform_tags": map[string]interface{}{"env": "prod"},
+			"defined_tags":  map[string]interface{}{"Oracle-Tags.CreatedBy": "user"},
+		},
+	}
+	user, other := "user", "other"
+
+	tests := []struct {
+		testName string
+		filter   *TagFilter
+		expected bool
+	}{
+		{"FreeformTag", &TagFilter{Key: "env"}, true},
+		{"MissingFreeformTag", &TagFilter{Key: "owner"}, false},
+		{"DefinedTag", &TagFilter{IsDefinedTag: true, Key: "Oracle-Tags.CreatedBy"}, true},
+		{"DefinedTagValue", &TagFilter{IsDefinedTag: true, Key: "Oracle-Tags.CreatedBy", Value: &user}, true},
+		{"OtherDefinedTagValue", &TagFilter{IsDefinedTag: true, Key: "Oracle-Tags.CreatedBy", Value: &other}, false},
+		{"MissingDefinedTag", &TagFilter{IsDefinedTag: true, Key: "Oracle-Tags.CreatedOn"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if ans := tt.filter.Filter(resource); ans != tt.expected {
+				t.Errorf("got %+v, want %+v", ans, tt.expected)
+			}
+		})
+	}
+}
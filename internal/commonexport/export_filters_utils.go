@@ -64,6 +64,14 @@ func walkAndGet(path string, data interface{}) (bool, []interface{}) {
 		return true, []interface{}{val.Interface()}
 	}
 
+	// numbers and booleans are compared as strings e.g. size_in_gbs
+	switch val.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		if path == "" {
+			return true, []interface{}{fmt.Sprint(val.Interface())}
+		}
+	}
+
 	return false, []interface{}{}
 }
 
@@ -150,6 +158,31 @@ func getFilterDeepCopy(filter ResourceFilter) (ResourceFilter, error) {
 			resourceFieldValueFilter.FieldPath,
 			values,
 		}, nil
+	case *AndFilter:
+		filters, err := GetFiltersDeepCopy(filter.(*AndFilter).Filters)
+		if err != nil {
+			return nil, err
+		}
+		return &AndFilter{filters}, nil
+	case *OrFilter:
+		filters, err := GetFiltersDeepCopy(filter.(*OrFilter).Filters)
+		if err != nil {
+			return nil, err
+		}
+		return &OrFilter{filters}, nil
+	case *NotFilter:
+		notFilter, err := getFilterDeepCopy(filter.(*NotFilter).NegatedFilter)
+		if err != nil {
+			return nil, err
+		}
+		return &NotFilter{notFilter}, nil
+	case *TagFilter:
+		tagFilter := *filter.(*TagFilter)
+		if tagFilter.Value != nil {
+			value := *tagFilter.Value
+			tagFilter.Value = &value
+		}
+		return &tagFilter, nil
 	}
 	return nil, fmt.Errorf("unable to convert filter %+v", filter)
 }
@@ -0,0 +1,109 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package commonexport
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// regex to match the placeholders of a naming template e.g. {{abbrev}} or {{tag.Name|display_name}}
+	namingTemplatePlaceholderPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	// regex to match the values of a placeholder: an attribute, tag.<key>, defined_tag.<namespace>.<key> or a keyword
+	namingTemplateValuePattern = regexp.MustCompile(`^[a-zA-Z0-9_\-.]+$`)
+	// regex to match the names that start with a letter or an underscore
+	terraformNameStartPattern = regexp.MustCompile(`^[a-zA-Z_]`)
+)
+
+// Keywords of a naming template, the other values of a placeholder are top-level attributes of the resource
+const (
+	NamingTemplateAbbreviation    = "abbrev"           // abbreviation of the resource type e.g. vcn
+	NamingTemplateType            = "type"             // resource type without the oci_ prefix e.g. core_vcn
+	NamingTemplateCompartmentPath = "compartment_path" // names of the compartments from the exported compartment to the compartment of the resource
+	NamingTemplateFreeformTag     = "tag."             // prefix of the key of a freeform tag e.g. tag.Name
+	NamingTemplateDefinedTag      = "defined_tag."     // prefix of the key of a defined tag e.g. defined_tag.Operations.CostCenter
+)
+
+// NamingTemplate generates the names of the discovered resources from the naming_template argument.
+// A placeholder may list values separated by | e.g. {{tag.Name|display_name}}, the first value found is used.
+type NamingTemplate struct {
+	Template            string
+	UsesCompartmentPath bool
+}
+
+// ResourceNamingTemplate is the template of the export, names are derived from display_name or name when it is nil
+var ResourceNamingTemplate *NamingTemplate
+
+func NewNamingTemplate(template string) (*NamingTemplate, error) {
+	placeholders := namingTemplatePlaceholderPattern.FindAllStringSubmatch(template, -1)
+	if len(placeholders) == 0 {
+		return nil, fmt.Errorf("[ERROR] naming_template %s should include at least one placeholder e.g. {{display_name}}", template)
+	}
+	if remainder := namingTemplatePlaceholderPattern.ReplaceAllString(template, ""); strings.ContainsAny(remainder, "{}") {
+		return nil, fmt.Errorf("[ERROR] naming_template %s has an unterminated placeholder", template)
+	}
+
+	namingTemplate := &NamingTemplate{Template: template}
+	for _, placeholder := range placeholders {
+		for _, value := range strings.Split(placeholder[1], "|") {
+			value = strings.TrimSpace(value)
+			if !namingTemplateValuePattern.MatchString(value) {
+				return nil, fmt.Errorf("[ERROR] invalid placeholder {{%s}} in naming_template %s", placeholder[1], template)
+			}
+			if value == NamingTemplateCompartmentPath {
+				namingTemplate.UsesCompartmentPath = true
+			}
+		}
+	}
+	return namingTemplate, nil
+}
+
+// GetTerraformName returns the unique name of the resource from the template, or false when a placeholder has no value
+// for the resource so that the default name is kept
+func (t *NamingTemplate) GetTerraformName(resource *OCIResource, compartmentPath []string) (string, bool) {
+	found := true
+	name := namingTemplatePlaceholderPattern.ReplaceAllStringFunc(t.Template, func(placeholder string) string {
+		for _, value := range strings.Split(namingTemplatePlaceholderPattern.FindStringSubmatch(placeholder)[1], "|") {
+			if result := getNamingTemplateValue(resource, strings.TrimSpace(value), compartmentPath); result != "" {
+				return result
+			}
+		}
+		found = false
+		return ""
+	})
+	if !found {
+		return "", false
+	}
+
+	if !terraformNameStartPattern.MatchString(name) {
+		name = fmt.Sprintf("export_%s", name)
+	}
+	return GetValidUniqueTerraformName(name), true
+}
+
+func getNamingTemplateValue(resource *OCIResource, value string, compartmentPath []string) string {
+	switch {
+	case value == NamingTemplateAbbreviation:
+		if resource.TerraformTypeInfo != nil {
+			return resource.TerraformTypeInfo.ResourceAbbreviation
+		}
+		return ""
+	case value == NamingTemplateType:
+		return strings.TrimPrefix(resource.TerraformClass, "oci_")
+	case value == NamingTemplateCompartmentPath:
+		return strings.Join(compartmentPath, "_")
+	case strings.HasPrefix(value, NamingTemplateFreeformTag):
+		freeformTags, _ := resource.SourceAttributes["freeform_tags"].(map[string]interface{})
+		tagValue, _ := freeformTags[strings.TrimPrefix(value, NamingTemplateFreeformTag)].(string)
+		return tagValue
+	case strings.HasPrefix(value, NamingTemplateDefinedTag):
+		definedTags, _ := resource.SourceAttributes["defined_tags"].(map[string]interface{})
+		tagValue, _ := definedTags[strings.TrimPrefix(value, NamingTemplateDefinedTag)].(string)
+		return tagValue
+	}
+	attributeValue, _ := resource.SourceAttributes[value].(string)
+	return attributeValue
+}
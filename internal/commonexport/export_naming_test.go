@@ -0,0 +1,88 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package commonexport
+
+import (
+	"testing"
+)
+
+func TestUnitNewNamingTemplate(t *testing.T) {
+	namingTemplate, err := NewNamingTemplate("{{abbrev}}_{{ compartment_path }}_{{tag.Name|display_name}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !namingTemplate.UsesCompartmentPath {
+		t.Errorf("expected the template to use the compartment path")
+	}
+
+	for _, template := range []string{"export", "{{display_name", "{{display_name}}}", "{{display name}}", "{{tag.Name|}}"} {
+		if _, err := NewNamingTemplate(template); err == nil {
+			t.Errorf("expected error for naming_template %q", template)
+		}
+	}
+}
+
+func TestUnitNamingTemplateGetTerraformName(t *testing.T) {
+	ResourceNameCount = map[string]int{}
+	defer func() { ResourceNameCount = map[string]int{} }()
+
+	resource := &OCIResource{
+		TerraformResource: TerraformResource{
+			TerraformClass:    "oci_core_vcn",
+			TerraformTypeInfo: &TerraformResourceHints{ResourceAbbreviation: "vcn"},
+		},
+		SourceAttributes: map[string]interface{}{
+			"display_name":  "my vcn",
+			"cidr_block":    "10.0.0.0/16",
+			"freeform_tags": map[string]interface{}{"Name": "prod.network"},
+			"defined_tags":  map[string]interface{}{"Operations.CostCenter": "42"},
+		},
+	}
+
+	tests := []struct {
+		template        string
+		compartmentPath []string
+		expected        string
+		found           bool
+	}{
+		{template: "{{abbrev}}_{{display_name}}", expected: "vcn_my-vcn", found: true},
+		{template: "{{abbrev}}_{{display_name}}", expected: "vcn_my-vcn_1", found: true},
+		{template: "{{type}}-{{tag.Name}}", expected: "core_vcn-prod-network", found: true},
+		{template: "{{tag.Owner|display_name}}", expected: "my-vcn", found: true},
+		{template: "{{defined_tag.Operations.CostCenter}}", expected: "export_42", found: true},
+		{template: "{{compartment_path}}_{{abbrev}}", compartmentPath: []string{"root", "network"}, expected: "root_network_vcn", found: true},
+		{template: "{{abbrev}}_{{name}}", found: false},
+		{template: "{{compartment_path}}_{{abbrev}}", found: false},
+	}
+	for _, test := range tests {
+		namingTemplate, err := NewNamingTemplate(test.template)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", test.template, err)
+		}
+		name, found := namingTemplate.GetTerraformName(resource, test.compartmentPath)
+		if found != test.found || name != test.expected {
+			t.Errorf("%s: expected %q (%v), got %q (%v)", test.template, test.expected, test.found, name, found)
+		}
+	}
+}
+
+func TestUnitValidateNamingTemplate(t *testing.T) {
+	outputDir := t.TempDir()
+	defer func() { ResourceNamingTemplate = nil }()
+
+	args := &ExportCommandArgs{OutputDir: &outputDir, Parallelism: 1, NamingTemplate: "{{abbrev}}_{{display_name}}"}
+	if err := args.Validate(); err != nil || ResourceNamingTemplate == nil || ResourceNamingTemplate.Template != args.NamingTemplate {
+		t.Errorf("expected the naming template to be set, got %v, error: %v", ResourceNamingTemplate, err)
+	}
+
+	args.NamingTemplate = ""
+	if err := args.Validate(); err != nil || ResourceNamingTemplate != nil {
+		t.Errorf("expected no naming template, got %v, error: %v", ResourceNamingTemplate, err)
+	}
+
+	args.NamingTemplate = "{{abbrev"
+	if err := args.Validate(); err == nil {
+		t.Errorf("expected error for an invalid naming_template")
+	}
+}
@@ -122,3 +122,23 @@ func exportAttributeForResourceAndGlobalLevel(sourceAttributes map[string]interf
 func exportAttributeFromDefaultList(defaultList []string, sourceAttributes map[string]interface{}, resourceName string, interpolationMap map[string]string) error {
 	return exportAttributeForGlobalLevel(sourceAttributes, resourceName, defaultList, interpolationMap)
 }
+
+/* Functions for handling sensitive attributes */
+
+// exportSensitiveAttributeAsVariable returns the resource level variable that replaces the value of a sensitive attribute,
+// the value is not written to the variable file so it does not end up in the configuration
+func exportSensitiveAttributeAsVariable(ociRes *OCIResource, attributePrefix string, tfAttribute string) string {
+	attribute := tfAttribute
+	if attributePrefix != "" {
+		// e.g. connection_details[0].password
+		attribute = strings.NewReplacer("[", ".", "]", "").Replace(attributePrefix) + globalvar.DotDelimiter + tfAttribute
+	}
+	variableName := utils.GetVarNameFromAttributeOfResources(attribute, ociRes.TerraformClass, ociRes.TerraformName)
+	utils.Debugf("[DEBUG] Exporting sensitive attribute %s of resource %s as variable %s", attribute, ociRes.TerraformName, variableName)
+
+	RefMapLock.Lock()
+	defer RefMapLock.Unlock()
+	Vars[variableName] = ""
+	SensitiveVars[variableName] = true
+	return variableName
+}
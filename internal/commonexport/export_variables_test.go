@@ -2,8 +2,12 @@ package commonexport
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
 	"github.com/oracle/terraform-provider-oci/internal/utils"
 
 	"github.com/stretchr/testify/assert"
@@ -256,3 +260,69 @@ func TestUnitExportAttributeForGlobalLevel(t *testing.T) {
 	assert.True(t, exist)
 	assert.Contains(t, v, "available_domain--ad1")
 }
+
+func TestUnitGetHCLStringFromMapSensitiveAttributes(t *testing.T) {
+	TfHclVersionvar = &TfHclVersion12{Value: TfVersion12}
+	resourceSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"display_name":   {Type: schema.TypeString, Optional: true},
+			"admin_password": {Type: schema.TypeString, Required: true, Sensitive: true},
+			"ssh_key":        {Type: schema.TypeString, Optional: true, Sensitive: true},
+			"connection": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+			}}},
+		},
+	}
+	sourceAttributes := map[string]interface{}{
+		"display_name":   "db",
+		"admin_password": "secret",
+		"ssh_key":        "key",
+		"connection":     []interface{}{map[string]interface{}{"password": "secret2"}},
+	}
+
+	tests := []struct {
+		mode          string
+		expected      string
+		sensitiveVars map[string]bool
+	}{
+		{
+			SensitiveAttributesVariable,
+			"admin_password = var.oci_database_db--admin_password--export_db\n" +
+				"connection {\npassword = var.oci_database_db--connection-0-password--export_db\n}\n" +
+				"display_name = \"db\"\n" +
+				"ssh_key = var.oci_database_db--ssh_key--export_db\n",
+			map[string]bool{
+				"oci_database_db--admin_password--export_db":        true,
+				"oci_database_db--connection-0-password--export_db": true,
+				"oci_database_db--ssh_key--export_db":               true,
+			},
+		},
+		{
+			SensitiveAttributesOmit,
+			"admin_password = \"" + globalvar.PlaceholderValueForMissingAttribute + "\"\t#Required attribute not found in discovery, placeholder value set to avoid plan failure\n" +
+				"connection {\n#password = <<Sensitive value omitted>>\n}\n" +
+				"display_name = \"db\"\n" +
+				"#ssh_key = <<Sensitive value omitted>>\n",
+			map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			Vars = map[string]string{}
+			SensitiveVars = map[string]bool{}
+			SensitiveAttributesExport = tt.mode
+			defer func() { SensitiveAttributesExport = "" }()
+
+			resource := &OCIResource{TerraformResource: TerraformResource{TerraformClass: "oci_database_db", TerraformName: "export_db"}}
+			builder := &strings.Builder{}
+			assert.NoError(t, GetHCLStringFromMap(builder, sourceAttributes, resourceSchema, map[string]string{}, resource, ""))
+			assert.Equal(t, tt.expected, builder.String())
+			assert.Equal(t, tt.sensitiveVars, SensitiveVars)
+			for variable := range tt.sensitiveVars {
+				assert.Equal(t, "", Vars[variable])
+				assert.NotContains(t, builder.String(), "secret")
+			}
+		})
+	}
+}
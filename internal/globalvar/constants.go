@@ -13,6 +13,7 @@ const (
 	ResourcePrincipal                     = "ResourcePrincipal"
 	RequestHeaderOpcOboToken              = "opc-obo-token"
 	RequestHeaderOpcHostSerial            = "opc-host-serial"
+	RequestHeaderOpcRetryToken            = "opc-retry-token"
 
 	//	HTTPRequestTimeout specifies the maximum duration for completing an HTTP request.
 	HTTPRequestTimeOut    = "HTTP_REQUEST_TIMEOUT"
@@ -35,6 +36,7 @@ const (
 	HasCorrectDomainNameEnv      = "has_correct_domain_name"
 	ClientHostOverridesEnv       = "CLIENT_HOST_OVERRIDES"
 	CustomCertLocationEnv        = "custom_cert_location"
+	DeterministicRetryTokenEnv   = "deterministic_retry_token"
 	AcceptLocalCerts             = "accept_local_certs"
 	JobOCID                      = "job-ocid"
 
@@ -49,10 +51,16 @@ const (
 	RegionAttrName                              = "region"
 	DisableAutoRetriesAttrName                  = "disable_auto_retries"
 	RetryDurationSecondsAttrName                = "retry_duration_seconds"
+	RetryAttrName                               = "retry"
+	RateLimitAttrName                           = "rate_limit"
 	OboTokenAttrName                            = "obo_token"
 	OboTokenPath                                = "obo_token_path"
 	ConfigFileProfileAttrName                   = "config_file_profile"
+	AssumeAttrName                              = "assume"
 	DefinedTagsToIgnore                         = "ignore_defined_tags"
+	IgnoreTagsAttrName                          = "ignore_tags"
+	DefaultFreeformTagsAttrName                 = "default_freeform_tags"
+	DefaultDefinedTagsAttrName                  = "default_defined_tags"
 	RealmSpecificServiceEndpointTemplateEnabled = "realm_specific_service_endpoint_template_enabled"
 
 	DefaultConfigFileName    = "config"
@@ -67,6 +75,12 @@ const (
 	DefaultStateFilename            = "terraform.tfstate"
 	VarsFile                        = "vars.tf"
 	ProviderFile                    = "provider.tf"
+	ModulesDir                      = "modules"
+	ModulesFile                     = "main.tf"
+	ModuleVariablesFile             = "variables.tf"
+	ModuleOutputsFile               = "outputs.tf"
+	ImportBlocksFile                = "import.tf"
+	DriftReportFile                 = "drift_report.json"
 	MissingRequiredAttributeWarning = `
 
 	
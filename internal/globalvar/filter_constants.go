@@ -1,8 +1,16 @@
 package globalvar
 
 const (
-	EqualToOperator    = "="
-	NotEqualToOperator = "!="
-	Type               = "type"
-	AttrName           = "AttrName"
+	EqualToOperator     = "="
+	NotEqualToOperator  = "!="
+	RegexOperator       = "~="
+	GreaterThanOperator = ">"
+	LessThanOperator    = "<"
+	AndOperator         = "AND"
+	OrOperator          = "OR"
+	NotOperator         = "NOT"
+	HasFreeformTag      = "HasFreeformTag"
+	HasDefinedTag       = "HasDefinedTag"
+	Type                = "type"
+	AttrName            = "AttrName"
 )
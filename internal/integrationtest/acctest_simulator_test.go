@@ -0,0 +1,128 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	oci_identity "github.com/oracle/oci-go-sdk/v65/identity"
+
+	"github.com/oracle/terraform-provider-oci/internal/acctest"
+	"github.com/oracle/terraform-provider-oci/internal/acctest/simulator"
+)
+
+const simulatorResourceConfig = `
+resource "oci_identity_compartment" "test_compartment" {
+	compartment_id = "${var.tenancy_ocid}"
+	name = "simulator"
+	description = "simulator"
+	enable_delete = true
+}
+
+resource "oci_identity_policy" "test_policy" {
+	compartment_id = "${oci_identity_compartment.test_compartment.id}"
+	name = "simulator"
+	description = "simulator"
+	statements = ["Allow group Administrators to manage all-resources in compartment simulator"]
+}
+
+data "oci_identity_availability_domains" "test_availability_domains" {
+	compartment_id = "${var.tenancy_ocid}"
+}
+
+resource "oci_core_vcn" "test_vcn" {
+	compartment_id = "${oci_identity_compartment.test_compartment.id}"
+	cidr_block = "10.0.0.0/16"
+	display_name = "%s"
+	dns_label = "vcn"
+}
+
+resource "oci_core_subnet" "test_subnet" {
+	compartment_id = "${oci_identity_compartment.test_compartment.id}"
+	vcn_id = "${oci_core_vcn.test_vcn.id}"
+	cidr_block = "10.0.1.0/24"
+	dns_label = "subnet"
+}
+
+resource "oci_core_instance" "test_instance" {
+	compartment_id = "${oci_identity_compartment.test_compartment.id}"
+	availability_domain = "${data.oci_identity_availability_domains.test_availability_domains.availability_domains.0.name}"
+	shape = "VM.Standard2.1"
+	create_vnic_details {
+		subnet_id = "${oci_core_subnet.test_subnet.id}"
+	}
+	source_details {
+		source_type = "image"
+		source_id = "ocid1.image.oc1..simulator"
+	}
+}
+
+data "oci_objectstorage_namespace" "test_namespace" {
+	compartment_id = "${var.tenancy_ocid}"
+}
+
+resource "oci_objectstorage_bucket" "test_bucket" {
+	compartment_id = "${oci_identity_compartment.test_compartment.id}"
+	namespace = "${data.oci_objectstorage_namespace.test_namespace.namespace}"
+	name = "simulator"
+}
+
+resource "oci_objectstorage_object" "test_object" {
+	namespace = "${oci_objectstorage_bucket.test_bucket.namespace}"
+	bucket = "${oci_objectstorage_bucket.test_bucket.name}"
+	object = "simulator"
+	content = "simulator"
+}
+`
+
+// issue-routing-tag: terraform/default
+func TestSimulatorResource_basic(t *testing.T) {
+	s := simulator.NewSimulator()
+	defer s.Close()
+	s.Setenv(t)
+
+	config := acctest.ProviderTestConfig()
+	var compartmentId string
+
+	acctest.ResourceTest(t, func(state *terraform.State) error {
+		client := acctest.GetTestClients(nil).IdentityClient()
+		response, err := client.GetCompartment(context.Background(), oci_identity.GetCompartmentRequest{CompartmentId: &compartmentId})
+		if err != nil {
+			return err
+		}
+		if response.LifecycleState != oci_identity.CompartmentLifecycleStateDeleted {
+			return fmt.Errorf("compartment %s is in the state %s", compartmentId, response.LifecycleState)
+		}
+		return nil
+	}, []resource.TestStep{
+		// verify Create
+		{
+			Config: config + fmt.Sprintf(simulatorResourceConfig, "vcn"),
+			Check: acctest.ComposeAggregateTestCheckFuncWrapper(
+				resource.TestCheckResourceAttr("oci_core_vcn.test_vcn", "vcn_domain_name", "vcn.oraclevcn.com"),
+				resource.TestCheckResourceAttr("oci_core_subnet.test_subnet", "virtual_router_ip", "10.0.1.1"),
+				resource.TestCheckResourceAttr("oci_core_instance.test_instance", "state", "RUNNING"),
+				resource.TestCheckResourceAttr("oci_core_instance.test_instance", "private_ip", "10.0.1.2"),
+				resource.TestCheckResourceAttr("oci_objectstorage_bucket.test_bucket", "namespace", s.Namespace),
+				resource.TestCheckResourceAttr("oci_objectstorage_object.test_object", "content_length", "9"),
+
+				func(state *terraform.State) (err error) {
+					compartmentId, err = acctest.FromInstanceState(state, "oci_identity_compartment.test_compartment", "id")
+					return err
+				},
+			),
+		},
+		// verify Update
+		{
+			Config: config + fmt.Sprintf(simulatorResourceConfig, "vcn2"),
+			Check: acctest.ComposeAggregateTestCheckFuncWrapper(
+				resource.TestCheckResourceAttr("oci_core_vcn.test_vcn", "display_name", "vcn2"),
+			),
+		},
+	})
+}
@@ -3732,6 +3732,12 @@ func init() {
 			F:            sweepCoreInstanceResource,
 		})
 	}
+	acctest.AddTagSweeper(&acctest.TagSweeper{
+		Name:         "CoreInstance",
+		ResourceType: "instance",
+		List:         listCoreInstanceToSweepByTag,
+		Delete:       deleteCoreInstanceToSweepByTag,
+	})
 }
 
 func sweepCoreInstanceResource(compartment string) error {
@@ -3801,3 +3807,41 @@ func instanceSweepResponseFetchOperation(client *tf_client.OracleClients, resour
 	})
 	return err
 }
+
+func listCoreInstanceToSweepByTag(compartment string) ([]acctest.SweepableResource, error) {
+	var resources []acctest.SweepableResource
+	compartmentId := compartment
+	computeClient := acctest.GetTestClients(&schema.ResourceData{}).ComputeClient()
+
+	listInstancesRequest := oci_core.ListInstancesRequest{}
+	listInstancesRequest.CompartmentId = &compartmentId
+	listInstancesRequest.LifecycleState = oci_core.InstanceLifecycleStateRunning
+	for {
+		listInstancesResponse, err := computeClient.ListInstances(context.Background(), listInstancesRequest)
+		if err != nil {
+			return resources, fmt.Errorf("Error getting Instance list for compartment id : %s , %s \n", compartmentId, err)
+		}
+		for _, instance := range listInstancesResponse.Items {
+			resources = append(resources, acctest.NewSweepableResource(instance.Id, instance.DisplayName, instance.FreeformTags, instance.TimeCreated))
+		}
+		if listInstancesResponse.OpcNextPage == nil {
+			return resources, nil
+		}
+		listInstancesRequest.Page = listInstancesResponse.OpcNextPage
+	}
+}
+
+func deleteCoreInstanceToSweepByTag(instanceId string) error {
+	computeClient := acctest.GetTestClients(&schema.ResourceData{}).ComputeClient()
+	terminateInstanceRequest := oci_core.TerminateInstanceRequest{}
+
+	terminateInstanceRequest.InstanceId = &instanceId
+
+	terminateInstanceRequest.RequestMetadata.RetryPolicy = tfresource.GetRetryPolicy(true, "core")
+	if _, err := computeClient.TerminateInstance(context.Background(), terminateInstanceRequest); err != nil {
+		return err
+	}
+	acctest.WaitTillCondition(acctest.TestAccProvider, &instanceId, instanceSweepWaitCondition, time.Duration(3*time.Minute),
+		instanceSweepResponseFetchOperation, "core", true)()
+	return nil
+}
@@ -482,6 +482,12 @@ func init() {
 			F:            sweepCoreSubnetResource,
 		})
 	}
+	acctest.AddTagSweeper(&acctest.TagSweeper{
+		Name:         "CoreSubnet",
+		ResourceType: "subnet",
+		List:         listCoreSubnetToSweepByTag,
+		Delete:       deleteCoreSubnetToSweepByTag,
+	})
 }
 
 func sweepCoreSubnetResource(compartment string) error {
@@ -551,3 +557,41 @@ func CoreSubnetSweepResponseFetchOperation(client *tf_client.OracleClients, reso
 	})
 	return err
 }
+
+func listCoreSubnetToSweepByTag(compartment string) ([]acctest.SweepableResource, error) {
+	var resources []acctest.SweepableResource
+	compartmentId := compartment
+	virtualNetworkClient := acctest.GetTestClients(&schema.ResourceData{}).VirtualNetworkClient()
+
+	listSubnetsRequest := oci_core.ListSubnetsRequest{}
+	listSubnetsRequest.CompartmentId = &compartmentId
+	listSubnetsRequest.LifecycleState = oci_core.SubnetLifecycleStateAvailable
+	for {
+		listSubnetsResponse, err := virtualNetworkClient.ListSubnets(context.Background(), listSubnetsRequest)
+		if err != nil {
+			return resources, fmt.Errorf("Error getting Subnet list for compartment id : %s , %s \n", compartmentId, err)
+		}
+		for _, subnet := range listSubnetsResponse.Items {
+			resources = append(resources, acctest.NewSweepableResource(subnet.Id, subnet.DisplayName, subnet.FreeformTags, subnet.TimeCreated))
+		}
+		if listSubnetsResponse.OpcNextPage == nil {
+			return resources, nil
+		}
+		listSubnetsRequest.Page = listSubnetsResponse.OpcNextPage
+	}
+}
+
+func deleteCoreSubnetToSweepByTag(subnetId string) error {
+	virtualNetworkClient := acctest.GetTestClients(&schema.ResourceData{}).VirtualNetworkClient()
+	deleteSubnetRequest := oci_core.DeleteSubnetRequest{}
+
+	deleteSubnetRequest.SubnetId = &subnetId
+
+	deleteSubnetRequest.RequestMetadata.RetryPolicy = tfresource.GetRetryPolicy(true, "core")
+	if _, err := virtualNetworkClient.DeleteSubnet(context.Background(), deleteSubnetRequest); err != nil {
+		return err
+	}
+	acctest.WaitTillCondition(acctest.TestAccProvider, &subnetId, CoreSubnetSweepWaitCondition, time.Duration(3*time.Minute),
+		CoreSubnetSweepResponseFetchOperation, "core", true)()
+	return nil
+}
@@ -339,6 +339,12 @@ func init() {
 			F:            sweepCoreVcnResource,
 		})
 	}
+	acctest.AddTagSweeper(&acctest.TagSweeper{
+		Name:         "CoreVcn",
+		ResourceType: "vcn",
+		List:         listCoreVcnToSweepByTag,
+		Delete:       deleteCoreVcnToSweepByTag,
+	})
 }
 
 func sweepCoreVcnResource(compartment string) error {
@@ -412,3 +418,41 @@ func CoreVcnSweepResponseFetchOperation(client *tf_client.OracleClients, resourc
 	})
 	return err
 }
+
+func listCoreVcnToSweepByTag(compartment string) ([]acctest.SweepableResource, error) {
+	var resources []acctest.SweepableResource
+	compartmentId := compartment
+	virtualNetworkClient := acctest.GetTestClients(&schema.ResourceData{}).VirtualNetworkClient()
+
+	listVcnsRequest := oci_core.ListVcnsRequest{}
+	listVcnsRequest.CompartmentId = &compartmentId
+	listVcnsRequest.LifecycleState = oci_core.VcnLifecycleStateAvailable
+	for {
+		listVcnsResponse, err := virtualNetworkClient.ListVcns(context.Background(), listVcnsRequest)
+		if err != nil {
+			return resources, fmt.Errorf("Error getting Vcn list for compartment id : %s , %s \n", compartmentId, err)
+		}
+		for _, vcn := range listVcnsResponse.Items {
+			resources = append(resources, acctest.NewSweepableResource(vcn.Id, vcn.DisplayName, vcn.FreeformTags, vcn.TimeCreated))
+		}
+		if listVcnsResponse.OpcNextPage == nil {
+			return resources, nil
+		}
+		listVcnsRequest.Page = listVcnsResponse.OpcNextPage
+	}
+}
+
+func deleteCoreVcnToSweepByTag(vcnId string) error {
+	virtualNetworkClient := acctest.GetTestClients(&schema.ResourceData{}).VirtualNetworkClient()
+	deleteVcnRequest := oci_core.DeleteVcnRequest{}
+
+	deleteVcnRequest.VcnId = &vcnId
+
+	deleteVcnRequest.RequestMetadata.RetryPolicy = tfresource.GetRetryPolicy(true, "core")
+	if _, err := virtualNetworkClient.DeleteVcn(context.Background(), deleteVcnRequest); err != nil {
+		return err
+	}
+	acctest.WaitTillCondition(acctest.TestAccProvider, &vcnId, CoreVcnSweepWaitCondition, time.Duration(3*time.Minute),
+		CoreVcnSweepResponseFetchOperation, "core", true)()
+	return nil
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package integrationtest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tf_identity "github.com/oracle/terraform-provider-oci/internal/service/identity"
+)
+
+// issue-routing-tag: terraform/default
+func TestUnitNormalizePolicyStatement(t *testing.T) {
+	tests := []struct {
+		statement  string
+		normalized string
+	}{
+		{
+			statement:  "Allow Group Administrators to read instances in tenancy",
+			normalized: "Allow group Administrators to read instances in tenancy",
+		},
+		{
+			statement:  "  allow   group  Admins,group 'Default'/'NetworkAdmins' TO MANAGE Virtual-Network-Family IN Compartment Prod:Network ",
+			normalized: "Allow group Admins, group 'Default'/'NetworkAdmins' to manage virtual-network-family in compartment Prod:Network",
+		},
+		{
+			statement:  "Allow dynamic-group id ocid1.dynamicgroup.oc1..aaaa to {vcn_read,SUBNET_READ} in compartment id ocid1.compartment.oc1..bbbb",
+			normalized: "Allow dynamic-group id ocid1.dynamicgroup.oc1..aaaa to {SUBNET_READ, VCN_READ} in compartment id ocid1.compartment.oc1..bbbb",
+		},
+		{
+			statement:  "Allow group Admins to {TAG_NAMESPACE_USE} in tenancy",
+			normalized: "Allow group Admins to {TAG_NAMESPACE_USE} in tenancy",
+		},
+		{
+			statement:  "Allow any-user to read buckets in compartment Logs where ALL { request.principal.type='instance' , target.bucket.name != 'private' }",
+			normalized: "Allow any-user to read buckets in compartment Logs where all {request.principal.type = 'instance', target.bucket.name != 'private'}",
+		},
+		{
+			statement:  "Allow group Ops to manage instances in tenancy where request.utc-timestamp.time-of-day BETWEEN '01:00:00Z' and '02:00:00Z'",
+			normalized: "Allow group Ops to manage instances in tenancy where request.utc-timestamp.time-of-day between '01:00:00Z' and '02:00:00Z'",
+		},
+		{
+			statement:  "Deny any-user to manage buckets in tenancy where any {request.region = 'phx', all {request.user.name = 'a', request.permission = 'BUCKET_DELETE'}}",
+			normalized: "Deny any-user to manage buckets in tenancy where any {request.region = 'phx', all {request.user.name = 'a', request.permission = 'BUCKET_DELETE'}}",
+		},
+		{
+			statement:  "define tenancy Acceptor as ocid1.tenancy.oc1..aaaa",
+			normalized: "Define tenancy Acceptor as ocid1.tenancy.oc1..aaaa",
+		},
+		{
+			statement:  "Endorse group NetworkAdmins to manage local-peering-to in tenancy Acceptor",
+			normalized: "Endorse group NetworkAdmins to manage local-peering-to in tenancy Acceptor",
+		},
+		{
+			statement:  "Admit group NetworkAdmins of tenancy Requestor to manage local-peering-from in compartment Shared",
+			normalized: "Admit group NetworkAdmins of tenancy Requestor to manage local-peering-from in compartment Shared",
+		},
+		{
+			statement:  "Endorse group Admins to associate local-peering-gateways in compartment Net with local-peering-gateways in tenancy Acceptor",
+			normalized: "Endorse group Admins to associate local-peering-gateways in compartment Net with local-peering-gateways in tenancy Acceptor",
+		},
+		{
+			statement:  "Allow service objectstorage-us-phoenix-1 to manage object-family in tenancy",
+			normalized: "Allow service objectstorage-us-phoenix-1 to manage object-family in tenancy",
+		},
+	}
+	for _, test := range tests {
+		normalized, err := tf_identity.NormalizePolicyStatement(test.statement)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.statement, err)
+			continue
+		}
+		if normalized != test.normalized {
+			t.Errorf("%s: expected %q, got %q", test.statement, test.normalized, normalized)
+		}
+	}
+}
+
+// issue-routing-tag: terraform/default
+func TestUnitNormalizePolicyStatement_invalid(t *testing.T) {
+	tests := []struct {
+		statement string
+		err       string
+	}{
+		{"Permit group Admins to read instances in tenancy", `expected Allow, Deny, Endorse, Admit, Define at position 1, found "Permit"`},
+		{"Allow group Admins to delete instances in tenancy", `expected a verb (inspect, read, use or manage) or a list of permissions at position 23, found "delete"`},
+		{"Allow Admins to read instances in tenancy", `expected a subject such as group <name>, dynamic-group <name>, any-user or any-group at position 7, found "Admins"`},
+		{"Allow group Admins to read instances", `expected in at the end of the statement`},
+		{"Allow group Admins to read instances in region phx", `expected compartment <name>, compartment id <ocid> or tenancy at position 41, found "region"`},
+		{"Allow group Admins to read instances in compartment id Prod", `expected an OCID at position 56, found "Prod"`},
+		{"Allow group Admins to read instances in tenancy where request.region = 'phx", `unterminated quoted string at position 72`},
+		{"Allow group Admins to read instances in tenancy where all {request.region = 'phx'", `expected "}" at the end of the statement`},
+		{"Allow group Admins to read instances in tenancy where request.region", `expected an operator (=, !=, before, after, between or in) at the end of the statement`},
+		{"Allow group Admins to {VCN_READ SUBNET_READ} in tenancy", `expected "," or "}" at position 33, found "SUBNET_READ"`},
+		{"Allow group Admins to read instances in tenancy Other", `expected the end of the statement at position 49, found "Other"`},
+		{"Define compartment Prod as ocid1.compartment.oc1..aaaa", `expected tenancy, group or dynamic-group at position 8, found "compartment"`},
+	}
+	for _, test := range tests {
+		_, err := tf_identity.NormalizePolicyStatement(test.statement)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected the error %q, got %v", test.statement, test.err, err)
+		}
+	}
+}
+
+// issue-routing-tag: terraform/default
+func TestUnitPolicyStatementsEqual(t *testing.T) {
+	tests := []struct {
+		old   string
+		new   string
+		equal bool
+	}{
+		{"Allow group Admins to read instances in tenancy", "allow GROUP Admins  to READ instances in tenancy", true},
+		{"Allow group Admins to {VCN_READ, SUBNET_READ} in tenancy", "Allow group Admins to {SUBNET_READ,VCN_READ} in tenancy", true},
+		{"Allow group Admins to read instances in tenancy", "Allow group admins to read instances in tenancy", false},
+		{"Allow group Admins to read instances in tenancy", "Allow group Admins to manage instances in tenancy", false},
+		{"Allow group Admins to read instances in tenancy where request.region = 'phx'", "Allow group Admins to read instances in tenancy where request.region = 'PHX'", false},
+		{"not a statement", "not a  statement", false},
+		{"not a statement", "not a statement", true},
+	}
+	for _, test := range tests {
+		if equal := tf_identity.PolicyStatementsEqual(test.old, test.new); equal != test.equal {
+			t.Errorf("%q and %q: expected equal to be %v, got %v", test.old, test.new, test.equal, equal)
+		}
+	}
+}
+
+// issue-routing-tag: terraform/default
+func TestUnitParsePolicyStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		parsed    tf_identity.PolicyStatement
+	}{
+		{
+			statement: "allow group Admins, dynamic-group id ocid1.dynamicgroup.oc1..aaaa to READ buckets in compartment Prod:Logs where target.bucket.name = 'logs'",
+			parsed: tf_identity.PolicyStatement{
+				Action:       "Allow",
+				Subjects:     []tf_identity.PolicySubject{{Kind: "group", Name: "Admins"}, {Kind: "dynamic-group", Id: "ocid1.dynamicgroup.oc1..aaaa"}},
+				Verb:         "read",
+				ResourceType: "buckets",
+				Location:     tf_identity.PolicyLocation{Kind: "compartment", Name: "Prod:Logs"},
+				Conditions:   "target.bucket.name = 'logs'",
+				Normalized:   "Allow group Admins, dynamic-group id ocid1.dynamicgroup.oc1..aaaa to read buckets in compartment Prod:Logs where target.bucket.name = 'logs'",
+			},
+		},
+		{
+			statement: "Deny any-user to {VCN_READ} in compartment id ocid1.compartment.oc1..bbbb",
+			parsed: tf_identity.PolicyStatement{
+				Action:      "Deny",
+				Subjects:    []tf_identity.PolicySubject{{Kind: "any-user"}},
+				Permissions: []string{"VCN_READ"},
+				Location:    tf_identity.PolicyLocation{Kind: "compartment", Id: "ocid1.compartment.oc1..bbbb"},
+				Normalized:  "Deny any-user to {VCN_READ} in compartment id ocid1.compartment.oc1..bbbb",
+			},
+		},
+		{
+			statement: "Endorse group NetworkAdmins to manage local-peering-to in tenancy Acceptor",
+			parsed: tf_identity.PolicyStatement{
+				Action:       "Endorse",
+				Subjects:     []tf_identity.PolicySubject{{Kind: "group", Name: "NetworkAdmins"}},
+				Verb:         "manage",
+				ResourceType: "local-peering-to",
+				Location:     tf_identity.PolicyLocation{Kind: "tenancy", Name: "Acceptor"},
+				Normalized:   "Endorse group NetworkAdmins to manage local-peering-to in tenancy Acceptor",
+			},
+		},
+	}
+	for _, test := range tests {
+		parsed, err := tf_identity.ParsePolicyStatement(test.statement)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.statement, err)
+			continue
+		}
+		if !reflect.DeepEqual(*parsed, test.parsed) {
+			t.Errorf("%s: expected %+v, got %+v", test.statement, test.parsed, *parsed)
+		}
+	}
+}
+
+// issue-routing-tag: terraform/default
+func TestUnitEvaluateEffectivePermissions(t *testing.T) {
+	// The path from the root compartment to the Logs compartment: tenancy > Prod > Logs
+	compartments := []tf_identity.PolicyCompartment{
+		{Id: "ocid1.tenancy.oc1..root", Name: "root"},
+		{Id: "ocid1.compartment.oc1..prod", Name: "Prod"},
+		{Id: "ocid1.compartment.oc1..logs", Name: "Logs"},
+	}
+	policy := func(compartmentId string, statements ...string) []tf_identity.EvaluatedPolicy {
+		return []tf_identity.EvaluatedPolicy{{Id: "ocid1.policy.oc1..test", Name: "test", CompartmentId: compartmentId, Statements: statements}}
+	}
+	admins := tf_identity.EffectivePermissionsRequest{Groups: []string{"Admins"}, Verb: "read", ResourceType: "buckets"}
+
+	tests := []struct {
+		name     string
+		request  tf_identity.EffectivePermissionsRequest
+		policies []tf_identity.EvaluatedPolicy
+		allowed  bool
+		matches  int
+	}{
+		{"tenancy", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to read buckets in tenancy"), true, 1},
+		{"higher verb", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to manage buckets in tenancy"), true, 1},
+		{"lower verb", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to inspect buckets in tenancy"), false, 0},
+		{"resource family", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to read object-family in tenancy"), true, 1},
+		{"all resources", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to manage all-resources in tenancy"), true, 1},
+		{"other resource type", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to manage instances in tenancy"), false, 0},
+		{"other group", admins, policy("ocid1.tenancy.oc1..root", "Allow group Ops to read buckets in tenancy"), false, 0},
+		{"default domain", admins, policy("ocid1.tenancy.oc1..root", "Allow group 'Default'/'admins' to read buckets in tenancy"), true, 1},
+		{"any-user", tf_identity.EffectivePermissionsRequest{Verb: "read", ResourceType: "buckets"}, policy("ocid1.tenancy.oc1..root", "Allow any-user to read buckets in tenancy"), true, 1},
+		{
+			"group id",
+			tf_identity.EffectivePermissionsRequest{Groups: []string{"ocid1.group.oc1..admins"}, Verb: "read", ResourceType: "buckets"},
+			policy("ocid1.tenancy.oc1..root", "Allow group id ocid1.group.oc1..admins to read buckets in tenancy"),
+			true, 1,
+		},
+		{
+			"dynamic group",
+			tf_identity.EffectivePermissionsRequest{DynamicGroups: []string{"Instances"}, Verb: "read", ResourceType: "buckets"},
+			policy("ocid1.tenancy.oc1..root", "Allow group Instances to read buckets in tenancy", "Allow dynamic-group Instances to read buckets in tenancy"),
+			true, 1,
+		},
+		{"tenancy of a compartment policy", admins, policy("ocid1.compartment.oc1..prod", "Allow group Admins to read buckets in tenancy"), false, 0},
+		{"compartment path", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to read buckets in compartment Prod:Logs"), true, 1},
+		{"parent compartment", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to read buckets in compartment Prod"), true, 1},
+		{"path relative to the policy", admins, policy("ocid1.compartment.oc1..prod", "Allow group Admins to read buckets in compartment Logs"), true, 1},
+		{"path with the policy compartment", admins, policy("ocid1.compartment.oc1..prod", "Allow group Admins to read buckets in compartment Prod:Logs"), true, 1},
+		{"other compartment", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to read buckets in compartment Dev"), false, 0},
+		{"compartment id", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to read buckets in compartment id ocid1.compartment.oc1..prod"), true, 1},
+		{"policy outside the path", admins, policy("ocid1.compartment.oc1..dev", "Allow group Admins to read buckets in tenancy"), false, 0},
+		{
+			"deny precedence",
+			admins,
+			policy("ocid1.tenancy.oc1..root", "Allow group Admins to manage buckets in tenancy", "Deny group Admins to read buckets in compartment Prod"),
+			false, 2,
+		},
+		{"conditions", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to read buckets in tenancy where target.bucket.name = 'logs'"), false, 1},
+		{
+			"conditional deny",
+			admins,
+			policy("ocid1.tenancy.oc1..root", "Allow group Admins to read buckets in tenancy", "Deny group Admins to read buckets in tenancy where request.region = 'phx'"),
+			true, 2,
+		},
+		{"permissions", admins, policy("ocid1.tenancy.oc1..root", "Allow group Admins to {BUCKET_READ} in tenancy"), false, 0},
+		{"invalid statement", admins, policy("ocid1.tenancy.oc1..root", "not a statement", "Allow group Admins to read buckets in tenancy"), true, 1},
+	}
+	for _, test := range tests {
+		allowed, matches := tf_identity.EvaluateEffectivePermissions(test.request, compartments, test.policies)
+		if allowed != test.allowed || len(matches) != test.matches {
+			t.Errorf("%s: expected allowed to be %v with %d matching statements, got %v with %v", test.name, test.allowed, test.matches, allowed, matches)
+		}
+	}
+}
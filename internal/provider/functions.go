@@ -0,0 +1,364 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+	"strings"
+	_ "unsafe" // go:linkname
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	oci_common "github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// shortNameRegion is the region key to region mapping of the SDK, which only exposes the lookup by key
+//
+//go:linkname shortNameRegion github.com/oracle/oci-go-sdk/v65/common.shortNameRegion
+var shortNameRegion map[string]oci_common.Region
+
+var policyVerbs = []string{"inspect", "read", "use", "manage"}
+
+var parsedOcidType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"version":       tftypes.String,
+	"resource_type": tftypes.String,
+	"realm":         tftypes.String,
+	"region":        tftypes.String,
+	"future_use":    tftypes.String,
+	"unique_id":     tftypes.String,
+}}
+
+// providerFunction is a provider-defined function, called as provider::oci::<name>(...) from Terraform 1.8
+type providerFunction struct {
+	definition *tfprotov5.Function
+	call       func(args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError)
+}
+
+func stringParameter(name string, description string) *tfprotov5.FunctionParameter {
+	return &tfprotov5.FunctionParameter{Name: name, Type: tftypes.String, Description: description}
+}
+
+// providerFunctions returns the provider-defined functions by name
+func providerFunctions() map[string]providerFunction {
+	return map[string]providerFunction{
+		"parse_ocid": {
+			definition: &tfprotov5.Function{
+				Summary: "Parse an OCID",
+				Description: "Returns the parts of an OCID of the format ocid1.<resource type>.<realm>.[region][.future use].<unique id> " +
+					"as an object with the attributes version, resource_type, realm, region, future_use and unique_id. " +
+					"The region and future_use are empty when the OCID does not have them.",
+				Parameters: []*tfprotov5.FunctionParameter{stringParameter("ocid", "The OCID to parse")},
+				Return:     &tfprotov5.FunctionReturn{Type: parsedOcidType},
+			},
+			call: callParseOcid,
+		},
+		"region_key": {
+			definition: &tfprotov5.Function{
+				Summary:     "Get the key of a region",
+				Description: "Returns the upper case key of a region, e.g. PHX for us-phoenix-1. The region can be given by its name or its key.",
+				Parameters:  []*tfprotov5.FunctionParameter{stringParameter("region", "The name or the key of the region")},
+				Return:      &tfprotov5.FunctionReturn{Type: tftypes.String},
+			},
+			call: callRegionKey,
+		},
+		"region_name": {
+			definition: &tfprotov5.Function{
+				Summary:     "Get the name of a region",
+				Description: "Returns the name of a region, e.g. us-phoenix-1 for PHX. The region can be given by its name or its key.",
+				Parameters:  []*tfprotov5.FunctionParameter{stringParameter("region", "The name or the key of the region")},
+				Return:      &tfprotov5.FunctionReturn{Type: tftypes.String},
+			},
+			call: callRegionName,
+		},
+		"availability_domain_name": {
+			definition: &tfprotov5.Function{
+				Summary: "Build the name of an availability domain",
+				Description: "Returns the name of an availability domain from the tenancy specific prefix, the region and the number " +
+					"of the availability domain, e.g. Uocm:PHX-AD-1 for Uocm, us-phoenix-1 and 1.",
+				Parameters: []*tfprotov5.FunctionParameter{
+					stringParameter("prefix", "The tenancy specific prefix of the availability domain names, e.g. Uocm"),
+					stringParameter("region", "The name or the key of the region"),
+					{Name: "number", Type: tftypes.Number, Description: "The number of the availability domain, from 1 to 3"},
+				},
+				Return: &tfprotov5.FunctionReturn{Type: tftypes.String},
+			},
+			call: callAvailabilityDomainName,
+		},
+		"policy_statement": {
+			definition: &tfprotov5.Function{
+				Summary: "Build an IAM policy statement",
+				Description: "Returns the policy statement Allow <subject> to <verb> <resources> in <location> [where <conditions>], " +
+					"e.g. Allow group Admins to manage all-resources in compartment Prod. " +
+					"Several conditions are combined with all { ... }.",
+				Parameters: []*tfprotov5.FunctionParameter{
+					stringParameter("subject", "The subject of the statement, e.g. group Admins, dynamic-group Instances or any-user"),
+					stringParameter("verb", "One of inspect, read, use or manage"),
+					stringParameter("resources", "The resource type or family, e.g. all-resources or virtual-network-family"),
+					stringParameter("location", "The location of the resources, e.g. tenancy or compartment Prod"),
+				},
+				VariadicParameter: stringParameter("conditions", "The conditions of the statement, e.g. request.permission = 'VCN_READ'"),
+				Return:            &tfprotov5.FunctionReturn{Type: tftypes.String},
+			},
+			call: callPolicyStatement,
+		},
+		"subnet_cidrs": {
+			definition: &tfprotov5.Function{
+				Summary: "Allocate consecutive subnet CIDR blocks in a VCN",
+				Description: "Returns a CIDR block for each number of additional prefix bits, allocated one after the other in the " +
+					"VCN CIDR block without overlapping, e.g. [\"10.0.0.0/24\", \"10.0.2.0/23\"] for 10.0.0.0/16 and [8, 7].",
+				Parameters: []*tfprotov5.FunctionParameter{
+					stringParameter("vcn_cidr", "The CIDR block of the VCN"),
+					{Name: "newbits", Type: tftypes.List{ElementType: tftypes.Number}, Description: "The number of bits added to the VCN prefix for each subnet"},
+				},
+				Return: &tfprotov5.FunctionReturn{Type: tftypes.List{ElementType: tftypes.String}},
+			},
+			call: callSubnetCidrs,
+		},
+	}
+}
+
+func functionArgumentError(index int, format string, args ...interface{}) *tfprotov5.FunctionError {
+	argument := int64(index)
+	return &tfprotov5.FunctionError{Text: fmt.Sprintf(format, args...), FunctionArgument: &argument}
+}
+
+func stringArgument(args []tftypes.Value, index int) (string, *tfprotov5.FunctionError) {
+	var value string
+	if err := args[index].As(&value); err != nil {
+		return "", functionArgumentError(index, "invalid argument: %v", err)
+	}
+	return value, nil
+}
+
+func intArgument(value tftypes.Value, index int) (int, *tfprotov5.FunctionError) {
+	var number big.Float
+	if err := value.As(&number); err != nil {
+		return 0, functionArgumentError(index, "invalid argument: %v", err)
+	}
+	result, accuracy := number.Int64()
+	if accuracy != big.Exact {
+		return 0, functionArgumentError(index, "%s is not a whole number", number.String())
+	}
+	return int(result), nil
+}
+
+// parseOcid returns the parts of an OCID by their attribute name in the parse_ocid result
+func parseOcid(ocid string) (map[string]string, error) {
+	parts := strings.Split(ocid, ".")
+	if len(parts) < 5 || len(parts) > 6 || !strings.HasPrefix(parts[0], "ocid") {
+		return nil, fmt.Errorf("%q is not an OCID of the format ocid1.<resource type>.<realm>.[region][.future use].<unique id>", ocid)
+	}
+	parsed := map[string]string{
+		"version":       parts[0],
+		"resource_type": parts[1],
+		"realm":         parts[2],
+		"region":        parts[3],
+		"future_use":    "",
+		"unique_id":     parts[len(parts)-1],
+	}
+	if len(parts) == 6 {
+		parsed["future_use"] = parts[4]
+	}
+	for _, key := range []string{"resource_type", "realm", "unique_id"} {
+		if parsed[key] == "" {
+			return nil, fmt.Errorf("the %s of the OCID %q is empty", key, ocid)
+		}
+	}
+	return parsed, nil
+}
+
+func callParseOcid(args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	ocid, ferr := stringArgument(args, 0)
+	if ferr != nil {
+		return tftypes.Value{}, ferr
+	}
+	parsed, err := parseOcid(ocid)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "%v", err)
+	}
+	attributes := make(map[string]tftypes.Value, len(parsed))
+	for key, value := range parsed {
+		attributes[key] = tftypes.NewValue(tftypes.String, value)
+	}
+	return tftypes.NewValue(parsedOcidType, attributes), nil
+}
+
+// regionKeyAndName returns the key and the name of a region given by its key or its name, from the regions known to the SDK
+func regionKeyAndName(region string) (string, string, error) {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if name, ok := shortNameRegion[region]; ok {
+		return strings.ToUpper(region), string(name), nil
+	}
+	if _, err := oci_common.Region(region).RealmID(); err == nil {
+		for key, name := range shortNameRegion {
+			if string(name) == region {
+				return strings.ToUpper(key), region, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("unknown region %q", region)
+}
+
+func callRegionKey(args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	region, ferr := stringArgument(args, 0)
+	if ferr != nil {
+		return tftypes.Value{}, ferr
+	}
+	key, _, err := regionKeyAndName(region)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "%v", err)
+	}
+	return tftypes.NewValue(tftypes.String, key), nil
+}
+
+func callRegionName(args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	region, ferr := stringArgument(args, 0)
+	if ferr != nil {
+		return tftypes.Value{}, ferr
+	}
+	_, name, err := regionKeyAndName(region)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(0, "%v", err)
+	}
+	return tftypes.NewValue(tftypes.String, name), nil
+}
+
+func callAvailabilityDomainName(args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	prefix, ferr := stringArgument(args, 0)
+	if ferr != nil {
+		return tftypes.Value{}, ferr
+	}
+	region, ferr := stringArgument(args, 1)
+	if ferr != nil {
+		return tftypes.Value{}, ferr
+	}
+	number, ferr := intArgument(args[2], 2)
+	if ferr != nil {
+		return tftypes.Value{}, ferr
+	}
+
+	if prefix = strings.TrimSuffix(strings.TrimSpace(prefix), ":"); prefix == "" {
+		return tftypes.Value{}, functionArgumentError(0, "the prefix of the availability domain name is empty")
+	}
+	key, _, err := regionKeyAndName(region)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(1, "%v", err)
+	}
+	if number < 1 || number > 3 {
+		return tftypes.Value{}, functionArgumentError(2, "the number of the availability domain must be between 1 and 3, got %d", number)
+	}
+	return tftypes.NewValue(tftypes.String, fmt.Sprintf("%s:%s-AD-%d", prefix, key, number)), nil
+}
+
+func callPolicyStatement(args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	values := make([]string, len(args))
+	for index := range args {
+		value, ferr := stringArgument(args, index)
+		if ferr != nil {
+			return tftypes.Value{}, ferr
+		}
+		if values[index] = strings.TrimSpace(value); values[index] == "" {
+			return tftypes.Value{}, functionArgumentError(index, "the argument is empty")
+		}
+	}
+
+	subject, verb, resources, location, conditions := values[0], strings.ToLower(values[1]), values[2], values[3], values[4:]
+	validVerb := false
+	for _, policyVerb := range policyVerbs {
+		validVerb = validVerb || verb == policyVerb
+	}
+	if !validVerb {
+		return tftypes.Value{}, functionArgumentError(1, "the verb must be one of %s, got %q", strings.Join(policyVerbs, ", "), values[1])
+	}
+
+	statement := fmt.Sprintf("Allow %s to %s %s in %s", subject, verb, resources, location)
+	switch len(conditions) {
+	case 0:
+	case 1:
+		statement += " where " + conditions[0]
+	default:
+		statement += " where all {" + strings.Join(conditions, ", ") + "}"
+	}
+	return tftypes.NewValue(tftypes.String, statement), nil
+}
+
+// subnetCidrs allocates a block for each newbits after the previous block in the VCN block, aligned to its size
+func subnetCidrs(vcnCidr string, newbits []int) ([]string, error) {
+	vcn, err := netip.ParsePrefix(vcnCidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VCN CIDR block %q: %v", vcnCidr, err)
+	}
+	vcn = vcn.Masked()
+	addressBits := vcn.Addr().BitLen()
+
+	start := new(big.Int).SetBytes(vcn.Addr().AsSlice())
+	end := new(big.Int).Add(start, new(big.Int).Lsh(big.NewInt(1), uint(addressBits-vcn.Bits())))
+	next := new(big.Int).Set(start)
+
+	cidrs := make([]string, 0, len(newbits))
+	for index, bits := range newbits {
+		prefixLength := vcn.Bits() + bits
+		if bits < 0 || prefixLength > addressBits {
+			return nil, fmt.Errorf("invalid newbits %d at index %d for the VCN CIDR block %s", bits, index, vcn)
+		}
+		size := new(big.Int).Lsh(big.NewInt(1), uint(addressBits-prefixLength))
+
+		// Round up to the next multiple of the block size so that the block is aligned
+		offset := new(big.Int).Sub(next, start)
+		offset.Add(offset, new(big.Int).Sub(size, big.NewInt(1)))
+		offset.Div(offset, size).Mul(offset, size)
+		blockStart := offset.Add(offset, start)
+		next = new(big.Int).Add(blockStart, size)
+		if next.Cmp(end) > 0 {
+			return nil, fmt.Errorf("no space left in the VCN CIDR block %s for the subnet %d with newbits %d", vcn, index, bits)
+		}
+
+		addressBytes := make([]byte, addressBits/8)
+		blockStart.FillBytes(addressBytes)
+		address, _ := netip.AddrFromSlice(addressBytes)
+		cidrs = append(cidrs, netip.PrefixFrom(address, prefixLength).String())
+	}
+	return cidrs, nil
+}
+
+func callSubnetCidrs(args []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	vcnCidr, ferr := stringArgument(args, 0)
+	if ferr != nil {
+		return tftypes.Value{}, ferr
+	}
+	var newbitsValues []tftypes.Value
+	if err := args[1].As(&newbitsValues); err != nil {
+		return tftypes.Value{}, functionArgumentError(1, "invalid argument: %v", err)
+	}
+	newbits := make([]int, len(newbitsValues))
+	for index, value := range newbitsValues {
+		bits, ferr := intArgument(value, 1)
+		if ferr != nil {
+			return tftypes.Value{}, ferr
+		}
+		newbits[index] = bits
+	}
+
+	cidrs, err := subnetCidrs(vcnCidr, newbits)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(1, "%v", err)
+	}
+	values := make([]tftypes.Value, len(cidrs))
+	for index, cidr := range cidrs {
+		values[index] = tftypes.NewValue(tftypes.String, cidr)
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values), nil
+}
+
+func providerFunctionNames(functions map[string]providerFunction) []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// callTestFunction calls a provider-defined function through the mux server as Terraform does
+func callTestFunction(t *testing.T, name string, args ...tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	server := newMuxServer(nil, providerFunctions())
+	request := &tfprotov5.CallFunctionRequest{Name: name}
+	for _, arg := range args {
+		dynamicValue, err := tfprotov5.NewDynamicValue(arg.Type(), arg)
+		if err != nil {
+			t.Fatalf("unable to encode the argument %v: %v", arg, err)
+		}
+		request.Arguments = append(request.Arguments, &dynamicValue)
+	}
+
+	resp, err := server.CallFunction(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+	result, err := resp.Result.Unmarshal(server.functions[name].definition.Return.Type)
+	if err != nil {
+		t.Fatalf("unable to decode the result: %v", err)
+	}
+	return result, nil
+}
+
+func stringValues(values ...string) []tftypes.Value {
+	result := make([]tftypes.Value, len(values))
+	for index, value := range values {
+		result[index] = tftypes.NewValue(tftypes.String, value)
+	}
+	return result
+}
+
+func TestUnitParseOcid(t *testing.T) {
+	tests := []struct {
+		ocid   string
+		output map[string]string
+	}{
+		{
+			ocid:   "ocid1.instance.oc1.phx.abyhqljrexample",
+			output: map[string]string{"version": "ocid1", "resource_type": "instance", "realm": "oc1", "region": "phx", "future_use": "", "unique_id": "abyhqljrexample"},
+		},
+		{
+			ocid:   "ocid1.tenancy.oc1..aaaaaaaaexample",
+			output: map[string]string{"version": "ocid1", "resource_type": "tenancy", "realm": "oc1", "region": "", "future_use": "", "unique_id": "aaaaaaaaexample"},
+		},
+		{
+			ocid:   "ocid1.volume.oc2.us-langley-1.future.abcexample",
+			output: map[string]string{"version": "ocid1", "resource_type": "volume", "realm": "oc2", "region": "us-langley-1", "future_use": "future", "unique_id": "abcexample"},
+		},
+		{ocid: "ocid1.instance.oc1.phx"},
+		{ocid: "id1.instance.oc1.phx.abc"},
+		{ocid: "ocid1.instance.oc1.phx."},
+	}
+	for _, test := range tests {
+		result, ferr := callTestFunction(t, "parse_ocid", stringValues(test.ocid)...)
+		if test.output == nil {
+			if ferr == nil || *ferr.FunctionArgument != 0 {
+				t.Errorf("%s: expected an error on the first argument, got %v", test.ocid, ferr)
+			}
+			continue
+		}
+		if ferr != nil {
+			t.Fatalf("%s: unexpected error: %s", test.ocid, ferr.Text)
+		}
+		attributes := map[string]tftypes.Value{}
+		if err := result.As(&attributes); err != nil {
+			t.Fatalf("%s: unexpected result %v", test.ocid, result)
+		}
+		output := map[string]string{}
+		for key, value := range attributes {
+			var s string
+			value.As(&s)
+			output[key] = s
+		}
+		if !reflect.DeepEqual(output, test.output) {
+			t.Errorf("%s: expected %v, got %v", test.ocid, test.output, output)
+		}
+	}
+}
+
+func TestUnitRegionFunctions(t *testing.T) {
+	tests := []struct {
+		region string
+		key    string
+		name   string
+	}{
+		{region: "us-phoenix-1", key: "PHX", name: "us-phoenix-1"},
+		{region: "PHX", key: "PHX", name: "us-phoenix-1"},
+		{region: " iad ", key: "IAD", name: "us-ashburn-1"},
+		{region: "EU-FRANKFURT-1", key: "FRA", name: "eu-frankfurt-1"},
+		{region: "mars-olympus-1"},
+	}
+	for _, test := range tests {
+		for function, want := range map[string]string{"region_key": test.key, "region_name": test.name} {
+			result, ferr := callTestFunction(t, function, stringValues(test.region)...)
+			if want == "" {
+				if ferr == nil || !strings.Contains(ferr.Text, "unknown region") {
+					t.Errorf("%s(%s): expected an unknown region error, got %v", function, test.region, ferr)
+				}
+				continue
+			}
+			if ferr != nil {
+				t.Fatalf("%s(%s): unexpected error: %s", function, test.region, ferr.Text)
+			}
+			if !result.Equal(tftypes.NewValue(tftypes.String, want)) {
+				t.Errorf("%s(%s): expected %s, got %v", function, test.region, want, result)
+			}
+		}
+	}
+}
+
+func TestUnitAvailabilityDomainName(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		region   string
+		number   int
+		output   string
+		argument int64
+	}{
+		{prefix: "Uocm", region: "us-phoenix-1", number: 1, output: "Uocm:PHX-AD-1"},
+		{prefix: "Uocm:", region: "iad", number: 3, output: "Uocm:IAD-AD-3"},
+		{prefix: " ", region: "iad", number: 1, argument: 0},
+		{prefix: "Uocm", region: "nowhere", number: 1, argument: 1},
+		{prefix: "Uocm", region: "iad", number: 4, argument: 2},
+	}
+	for _, test := range tests {
+		args := append(stringValues(test.prefix, test.region), tftypes.NewValue(tftypes.Number, test.number))
+		result, ferr := callTestFunction(t, "availability_domain_name", args...)
+		if test.output == "" {
+			if ferr == nil || *ferr.FunctionArgument != test.argument {
+				t.Errorf("%v: expected an error on the argument %d, got %v", test, test.argument, ferr)
+			}
+			continue
+		}
+		if ferr != nil {
+			t.Fatalf("%v: unexpected error: %s", test, ferr.Text)
+		}
+		if !result.Equal(tftypes.NewValue(tftypes.String, test.output)) {
+			t.Errorf("expected %s, got %v", test.output, result)
+		}
+	}
+}
+
+func TestUnitPolicyStatement(t *testing.T) {
+	tests := []struct {
+		args   []string
+		output string
+	}{
+		{
+			args:   []string{"group Admins", "manage", "all-resources", "tenancy"},
+			output: "Allow group Admins to manage all-resources in tenancy",
+		},
+		{
+			args:   []string{"dynamic-group Instances", "READ", "buckets", "compartment Prod", "target.bucket.name = 'logs'"},
+			output: "Allow dynamic-group Instances to read buckets in compartment Prod where target.bucket.name = 'logs'",
+		},
+		{
+			args:   []string{"any-user", "use", "virtual-network-family", "compartment Prod", "request.user.id = 'a'", "request.region = 'phx'"},
+			output: "Allow any-user to use virtual-network-family in compartment Prod where all {request.user.id = 'a', request.region = 'phx'}",
+		},
+		{args: []string{"group Admins", "delete", "buckets", "tenancy"}},
+		{args: []string{"group Admins", "read", "", "tenancy"}},
+	}
+	for _, test := range tests {
+		result, ferr := callTestFunction(t, "policy_statement", stringValues(test.args...)...)
+		if test.output == "" {
+			if ferr == nil {
+				t.Errorf("%v: expected an error, got %v", test.args, result)
+			}
+			continue
+		}
+		if ferr != nil {
+			t.Fatalf("%v: unexpected error: %s", test.args, ferr.Text)
+		}
+		if !result.Equal(tftypes.NewValue(tftypes.String, test.output)) {
+			t.Errorf("expected %s, got %v", test.output, result)
+		}
+	}
+}
+
+func TestUnitSubnetCidrs(t *testing.T) {
+	tests := []struct {
+		vcnCidr string
+		newbits []int
+		output  []string
+	}{
+		{vcnCidr: "10.0.0.0/16", newbits: []int{8, 7, 8}, output: []string{"10.0.0.0/24", "10.0.2.0/23", "10.0.4.0/24"}},
+		{vcnCidr: "10.0.1.7/24", newbits: []int{2, 2, 2, 2}, output: []string{"10.0.1.0/26", "10.0.1.64/26", "10.0.1.128/26", "10.0.1.192/26"}},
+		{vcnCidr: "2603:c020::/56", newbits: []int{8, 8}, output: []string{"2603:c020::/64", "2603:c020:0:1::/64"}},
+		{vcnCidr: "10.0.0.0/24", newbits: []int{1, 1, 1}},
+		{vcnCidr: "10.0.0.0/24", newbits: []int{9}},
+		{vcnCidr: "10.0.0.0/33", newbits: []int{1}},
+	}
+	for _, test := range tests {
+		newbits := make([]tftypes.Value, len(test.newbits))
+		for index, bits := range test.newbits {
+			newbits[index] = tftypes.NewValue(tftypes.Number, bits)
+		}
+		result, ferr := callTestFunction(t, "subnet_cidrs", tftypes.NewValue(tftypes.String, test.vcnCidr), tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, newbits))
+		if test.output == nil {
+			if ferr == nil {
+				t.Errorf("%s %v: expected an error, got %v", test.vcnCidr, test.newbits, result)
+			}
+			continue
+		}
+		if ferr != nil {
+			t.Fatalf("%s %v: unexpected error: %s", test.vcnCidr, test.newbits, ferr.Text)
+		}
+		if want := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, stringValues(test.output...)); !result.Equal(want) {
+			t.Errorf("%s %v: expected %v, got %v", test.vcnCidr, test.newbits, want, result)
+		}
+	}
+}
+
+func TestUnitMuxServerFunctions(t *testing.T) {
+	server := ProviderServer(Provider())
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schemaResp.ResourceSchemas) == 0 || schemaResp.Functions["parse_ocid"] == nil {
+		t.Errorf("expected the schema to have the resources and the functions, got %d resources and the functions %v", len(schemaResp.ResourceSchemas), schemaResp.Functions)
+	}
+
+	metadataResp, err := server.GetMetadata(context.Background(), &tfprotov5.GetMetadataRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, function := range metadataResp.Functions {
+		names = append(names, function.Name)
+	}
+	if want := providerFunctionNames(providerFunctions()); !reflect.DeepEqual(names, want) {
+		t.Errorf("expected the functions %v, got %v", want, names)
+	}
+
+	functionServer := server.(tfprotov5.FunctionServer)
+	callResp, err := functionServer.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{Name: "unknown"})
+	if err != nil || callResp.Error == nil || !strings.Contains(callResp.Error.Text, "Function Not Found") {
+		t.Errorf("expected a function not found error, got %v, %v", callResp, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+muxServer serves the SDKv2 provider along with the provider-defined functions, which the SDKv2 does not support.
+The function RPCs are answered from the functions and every other RPC is passed to the SDKv2 server, the schema and
+the metadata of the SDKv2 server are completed with the functions.
+*/
+type muxServer struct {
+	tfprotov5.ProviderServer
+	functions map[string]providerFunction
+}
+
+// ProviderServer returns the server of the provider with its provider-defined functions
+func ProviderServer(provider *schema.Provider) tfprotov5.ProviderServer {
+	return newMuxServer(schema.NewGRPCProviderServer(provider), providerFunctions())
+}
+
+func newMuxServer(server tfprotov5.ProviderServer, functions map[string]providerFunction) *muxServer {
+	return &muxServer{ProviderServer: server, functions: functions}
+}
+
+func (s *muxServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	resp.Functions = resp.Functions[:0]
+	for _, name := range providerFunctionNames(s.functions) {
+		resp.Functions = append(resp.Functions, tfprotov5.FunctionMetadata{Name: name})
+	}
+	return resp, nil
+}
+
+func (s *muxServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	resp.Functions = s.functionDefinitions()
+	return resp, nil
+}
+
+func (s *muxServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	return &tfprotov5.GetFunctionsResponse{Functions: s.functionDefinitions()}, nil
+}
+
+func (s *muxServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	function, ok := s.functions[req.Name]
+	if !ok {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{Text: fmt.Sprintf("Function Not Found: No function named %q was found in the provider.", req.Name)},
+		}, nil
+	}
+
+	args := make([]tftypes.Value, len(req.Arguments))
+	for index, argument := range req.Arguments {
+		parameter := function.definition.VariadicParameter
+		if index < len(function.definition.Parameters) {
+			parameter = function.definition.Parameters[index]
+		}
+		if parameter == nil {
+			return &tfprotov5.CallFunctionResponse{
+				Error: &tfprotov5.FunctionError{Text: fmt.Sprintf("function %s takes %d arguments, got %d", req.Name, len(function.definition.Parameters), len(req.Arguments))},
+			}, nil
+		}
+		value, err := argument.Unmarshal(parameter.Type)
+		if err != nil {
+			return &tfprotov5.CallFunctionResponse{Error: functionArgumentError(index, "unable to read the argument %s: %v", parameter.Name, err)}, nil
+		}
+		args[index] = value
+	}
+	if len(args) < len(function.definition.Parameters) {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{Text: fmt.Sprintf("function %s takes %d arguments, got %d", req.Name, len(function.definition.Parameters), len(req.Arguments))},
+		}, nil
+	}
+
+	result, ferr := function.call(args)
+	if ferr != nil {
+		return &tfprotov5.CallFunctionResponse{Error: ferr}, nil
+	}
+	dynamicValue, err := tfprotov5.NewDynamicValue(function.definition.Return.Type, result)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{Error: &tfprotov5.FunctionError{Text: fmt.Sprintf("unable to encode the result of %s: %v", req.Name, err)}}, nil
+	}
+	return &tfprotov5.CallFunctionResponse{Result: &dynamicValue}, nil
+}
+
+func (s *muxServer) functionDefinitions() map[string]*tfprotov5.Function {
+	definitions := make(map[string]*tfprotov5.Function, len(s.functions))
+	for name, function := range s.functions {
+		definitions[name] = function.definition
+	}
+	return definitions
+}
@@ -14,6 +14,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tf_core "github.com/oracle/terraform-provider-oci/internal/service/core"
@@ -81,6 +82,69 @@ func (p oboTokenProviderFromEnv) OboToken() (string, error) {
 	return utils.GetEnvSettingWithBlankDefault(globalvar.OboTokenAttrName), nil
 }
 
+// staticOboTokenProvider provides an obo token that was set explicitly in the configuration
+type staticOboTokenProvider string
+
+func (p staticOboTokenProvider) OboToken() (string, error) {
+	return string(p), nil
+}
+
+// oboTokenProviderFromFile reads the obo token from a file, and reads it again whenever the file changes so that
+// a token that was refreshed by an external process is picked up without restarting the provider
+type oboTokenProviderFromFile struct {
+	path    string
+	mutex   sync.Mutex
+	modTime time.Time
+	token   string
+}
+
+func (p *oboTokenProviderFromFile) OboToken() (string, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.token == "" || !info.ModTime().Equal(p.modTime) {
+		token, err := utils.GetTokenFromFile(p.path)
+		if err != nil {
+			return "", err
+		}
+		p.token = strings.TrimSpace(token)
+		p.modTime = info.ModTime()
+	}
+	return p.token, nil
+}
+
+// assumedIdentityConfigurationProvider chains the identity from the `assume` block on top of the base auth.
+// Requests are still signed with the base auth, but they carry the delegation token of the assumed identity
+// and are scoped to its tenancy and region.
+type assumedIdentityConfigurationProvider struct {
+	oci_common.ConfigurationProvider
+	tenancyOCID      string
+	region           string
+	oboTokenProvider OboTokenProvider
+}
+
+func (p assumedIdentityConfigurationProvider) TenancyOCID() (string, error) {
+	if p.tenancyOCID != "" {
+		return p.tenancyOCID, nil
+	}
+	return p.ConfigurationProvider.TenancyOCID()
+}
+
+func (p assumedIdentityConfigurationProvider) Region() (string, error) {
+	if p.region != "" {
+		return p.region, nil
+	}
+	return p.ConfigurationProvider.Region()
+}
+
+func (p assumedIdentityConfigurationProvider) OboToken() (string, error) {
+	return p.oboTokenProvider.OboToken()
+}
+
 func tfVarName(attrName string) string {
 	return globalvar.TfEnvPrefix + attrName
 }
@@ -107,7 +171,17 @@ func init() {
 			"The actual retry duration may be longer due to jittering of retry operations. This value is ignored if the `disable_auto_retries` field is set to true.",
 		globalvar.ConfigFileProfileAttrName:                   "(Optional) The profile name to be used from config file, if not set it will be DEFAULT.",
 		globalvar.DefinedTagsToIgnore:                         "(Optional) List of defined tags keys that Terraform should ignore when planning creates and updates to the associated remote object",
+		globalvar.DefaultFreeformTagsAttrName:                 "(Optional) Free-form tags that are merged into the `freeform_tags` of every resource that supports them. Tags set on the resource take precedence.",
+		globalvar.DefaultDefinedTagsAttrName:                  "(Optional) Defined tags, in the form `namespace.key`, that are merged into the `defined_tags` of every resource that supports them. Tags set on the resource take precedence.",
 		globalvar.RealmSpecificServiceEndpointTemplateEnabled: "(Optional) flags to enable realm specific service endpoint.",
+		globalvar.RetryAttrName: "(Optional) Retry behavior for a service, e.g. `identity`, `database` or `object_storage`. Use `default` for the services without their own block.\n" +
+			"These blocks are ignored if the `disable_auto_retries` field is set to true.",
+		globalvar.AssumeAttrName: "(Optional) An identity to assume on top of the configured auth, e.g. to operate in a customer tenancy from an instance principal.\n" +
+			"Requests are signed with the configured auth and carry the delegation (OBO) token of the assumed identity.",
+		globalvar.IgnoreTagsAttrName: "(Optional) Free-form and defined tag keys that Terraform should ignore when planning creates and updates to the associated remote object.\n" +
+			"Keys are matched case-insensitively and can be glob patterns, e.g. `ops-*` or `Operations.*`.",
+		globalvar.RateLimitAttrName: "(Optional) Client side limits for the requests sent by a client, e.g. `oci_identity.IdentityClient` or `oci_core.VirtualNetworkClient`.\n" +
+			"Throttled responses with a `Retry-After` header hold back the requests of that client for the requested time.",
 	}
 }
 
@@ -188,12 +262,130 @@ func SchemaMap() map[string]*schema.Schema {
 			Description: descriptions[globalvar.RetryDurationSecondsAttrName],
 			DefaultFunc: schema.MultiEnvDefaultFunc([]string{tfVarName(globalvar.RetryDurationSecondsAttrName), ociVarName(globalvar.RetryDurationSecondsAttrName)}, nil),
 		},
+		globalvar.RetryAttrName: {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: descriptions[globalvar.RetryAttrName],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"service": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The service the retry behavior applies to, or `default`.",
+					},
+					"max_duration_seconds": {
+						Type:        schema.TypeInt,
+						Optional:    true,
+						Default:     -1,
+						Description: "The maximum duration (in seconds) to retry a retriable error. Set to 0 to fail fast. A negative value keeps the provider default.",
+					},
+					"backoff_base_seconds": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      1,
+						ValidateFunc: validation.IntAtLeast(1),
+						Description:  "The base of the quadratic backoff between attempts, the backoff before attempt n is `backoff_base_seconds * 2 * n^2`.",
+					},
+					"backoff_cap_seconds": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      288,
+						ValidateFunc: validation.IntAtLeast(1),
+						Description:  "The maximum backoff (in seconds) between attempts.",
+					},
+					"jitter": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+						Description: "Randomize the backoff between attempts to avoid clustering.",
+					},
+					"retryable_status_codes": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeInt},
+						Description: "HTTP status codes to retry. When this or `retryable_service_codes` is set, no other errors are retried.",
+					},
+					"retryable_service_codes": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Service error codes to retry, e.g. `TooManyRequests`. When this or `retryable_status_codes` is set, no other errors are retried.",
+					},
+				},
+			},
+		},
+		globalvar.RateLimitAttrName: {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: descriptions[globalvar.RateLimitAttrName],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"client": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The registered name of the client the limits apply to, e.g. `oci_identity.IdentityClient`.",
+					},
+					"requests_per_second": {
+						Type:         schema.TypeFloat,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.FloatAtLeast(0),
+						Description:  "The sustained rate of requests sent by the client. 0 means no rate limit.",
+					},
+					"burst": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+						Description:  "The number of requests that can be sent at once above the sustained rate. Defaults to `requests_per_second` rounded up.",
+					},
+					"max_concurrent_requests": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+						Description:  "The maximum number of requests of the client in flight at the same time. 0 means no limit.",
+					},
+				},
+			},
+		},
 		globalvar.ConfigFileProfileAttrName: {
 			Type:        schema.TypeString,
 			Optional:    true,
 			Description: descriptions[globalvar.ConfigFileProfileAttrName],
 			DefaultFunc: schema.MultiEnvDefaultFunc([]string{tfVarName(globalvar.ConfigFileProfileAttrName), ociVarName(globalvar.ConfigFileProfileAttrName)}, nil),
 		},
+		globalvar.AssumeAttrName: {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: descriptions[globalvar.AssumeAttrName],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					globalvar.TenancyOcidAttrName: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "(Optional) The OCID of the tenancy of the assumed identity.",
+					},
+					globalvar.RegionAttrName: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "(Optional) The region for API connections of the assumed identity. Defaults to the provider region.",
+					},
+					globalvar.OboTokenAttrName: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "(Optional) The delegation (OBO) token of the assumed identity.",
+					},
+					globalvar.OboTokenPath: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "(Optional) The path to a file with the delegation (OBO) token of the assumed identity. The file is read again whenever it changes.",
+					},
+				},
+			},
+		},
 		globalvar.DefinedTagsToIgnore: {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -201,6 +393,38 @@ func SchemaMap() map[string]*schema.Schema {
 			Description: descriptions[globalvar.DefinedTagsToIgnore],
 			MaxItems:    100,
 		},
+		globalvar.IgnoreTagsAttrName: {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: descriptions[globalvar.IgnoreTagsAttrName],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"freeform_tags": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"defined_tags": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		globalvar.DefaultFreeformTagsAttrName: {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: descriptions[globalvar.DefaultFreeformTagsAttrName],
+		},
+		globalvar.DefaultDefinedTagsAttrName: {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: descriptions[globalvar.DefaultDefinedTagsAttrName],
+		},
 		globalvar.RealmSpecificServiceEndpointTemplateEnabled: {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -241,7 +465,14 @@ func ResourcesMap() map[string]*schema.Resource {
 }
 
 func ProviderConfig(d *schema.ResourceData) (interface{}, error) {
-	tf_resource.DefinedTagsToSuppress = IgnoreDefinedTags(d)
+	ignoreFreeformTags, ignoreDefinedTags := IgnoreTags(d)
+	tf_resource.DefinedTagsToSuppress = append(IgnoreDefinedTags(d), ignoreDefinedTags...)
+	tf_resource.FreeformTagsToSuppress = ignoreFreeformTags
+	tf_resource.DefaultFreeformTags = DefaultTags(d, globalvar.DefaultFreeformTagsAttrName)
+	tf_resource.DefaultDefinedTags = DefaultTags(d, globalvar.DefaultDefinedTagsAttrName)
+	if _, err := tf_resource.MapToDefinedTags(tf_resource.DefaultDefinedTags); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", globalvar.DefaultDefinedTagsAttrName, err)
+	}
 	tf_resource.RealmSpecificServiceEndpointTemplateEnabled = realmSpecificServiceEndpointTemplateEnabled(d)
 	clients := &tf_client.OracleClients{
 		SdkClientMap:  make(map[string]interface{}, len(tf_client.OracleClientRegistrationsVar.RegisteredClients)),
@@ -251,14 +482,28 @@ func ProviderConfig(d *schema.ResourceData) (interface{}, error) {
 	if d.Get(globalvar.DisableAutoRetriesAttrName).(bool) {
 		tf_resource.ShortRetryTime = 0
 		tf_resource.LongRetryTime = 0
-	} else if retryDurationSeconds, exists := d.GetOkExists(globalvar.RetryDurationSecondsAttrName); exists {
-		val := time.Duration(retryDurationSeconds.(int)) * time.Second
-		if retryDurationSeconds.(int) < 0 {
-			// Retry for maximum amount of time, if a negative value was specified
-			val = time.Duration(globalvar.MaxInt64)
+	} else {
+		if retryDurationSeconds, exists := d.GetOkExists(globalvar.RetryDurationSecondsAttrName); exists {
+			val := time.Duration(retryDurationSeconds.(int)) * time.Second
+			if retryDurationSeconds.(int) < 0 {
+				// Retry for maximum amount of time, if a negative value was specified
+				val = time.Duration(globalvar.MaxInt64)
+			}
+			tf_resource.ConfiguredRetryDuration = &val
 		}
-		tf_resource.ConfiguredRetryDuration = &val
+
+		retryConfigurations, err := RetryConfigurations(d)
+		if err != nil {
+			return nil, err
+		}
+		tf_resource.ServiceRetryConfigurations = retryConfigurations
+	}
+
+	rateLimitConfigurations, err := RateLimitConfigurations(d)
+	if err != nil {
+		return nil, err
 	}
+	tf_client.SetRateLimitConfigurations(rateLimitConfigurations)
 
 	sdkConfigProvider, err := GetSdkConfigProvider(d, clients)
 	if err != nil {
@@ -318,9 +563,56 @@ func GetSdkConfigProvider(d *schema.ResourceData, clients *tf_client.OracleClien
 		return nil, err
 	}
 
+	sdkConfigProvider, err = getAssumedIdentityConfigProvider(d, sdkConfigProvider)
+	if err != nil {
+		return nil, err
+	}
+	if region, err := sdkConfigProvider.Region(); err == nil {
+		clients.Configuration["region"] = region
+	}
+
 	return sdkConfigProvider, nil
 }
 
+// getAssumedIdentityConfigProvider chains the `assume` block, if any, to the config provider of the base auth
+func getAssumedIdentityConfigProvider(d schemaResourceData, baseConfigProvider oci_common.ConfigurationProvider) (oci_common.ConfigurationProvider, error) {
+	assume, ok := d.GetOkExists(globalvar.AssumeAttrName)
+	if !ok {
+		return baseConfigProvider, nil
+	}
+	assumeList, ok := assume.([]interface{})
+	if !ok || len(assumeList) == 0 {
+		return baseConfigProvider, nil
+	}
+	assumeMap, ok := assumeList[0].(map[string]interface{})
+	if !ok {
+		return baseConfigProvider, nil
+	}
+
+	var oboTokenProvider OboTokenProvider
+	if tokenPath, _ := assumeMap[globalvar.OboTokenPath].(string); tokenPath != "" {
+		oboTokenProvider = &oboTokenProviderFromFile{path: utils.ExpandPath(tokenPath)}
+	} else if token, _ := assumeMap[globalvar.OboTokenAttrName].(string); token != "" {
+		oboTokenProvider = staticOboTokenProvider(token)
+	} else {
+		return nil, fmt.Errorf("one of %s or %s must be set in the %s block", globalvar.OboTokenAttrName, globalvar.OboTokenPath, globalvar.AssumeAttrName)
+	}
+	if _, err := oboTokenProvider.OboToken(); err != nil {
+		return nil, fmt.Errorf("can not read the token of the identity to assume: %v", err)
+	}
+
+	tenancyOCID, _ := assumeMap[globalvar.TenancyOcidAttrName].(string)
+	region, _ := assumeMap[globalvar.RegionAttrName].(string)
+	log.Printf("[DEBUG] Assuming identity in tenancy '%s' and region '%s'", tenancyOCID, region)
+
+	return assumedIdentityConfigurationProvider{
+		ConfigurationProvider: baseConfigProvider,
+		tenancyOCID:           tenancyOCID,
+		region:                region,
+		oboTokenProvider:      oboTokenProvider,
+	}, nil
+}
+
 func getConfigProviders(d *schema.ResourceData, auth string) ([]oci_common.ConfigurationProvider, error) {
 	var configProviders []oci_common.ConfigurationProvider
 
@@ -519,6 +811,139 @@ func IgnoreDefinedTags(d schemaResourceData) []string {
 	}
 	return nil
 }
+
+// IgnoreTags returns the free-form and defined tag keys/patterns from the ignore_tags block
+func IgnoreTags(d schemaResourceData) (freeformTags []string, definedTags []string) {
+	ignoreTags, ok := d.GetOkExists(globalvar.IgnoreTagsAttrName)
+	if !ok {
+		return nil, nil
+	}
+	ignoreTagsList, ok := ignoreTags.([]interface{})
+	if !ok || len(ignoreTagsList) == 0 {
+		return nil, nil
+	}
+	ignoreTagsMap, ok := ignoreTagsList[0].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	return interfaceListToStrings(ignoreTagsMap["freeform_tags"]), interfaceListToStrings(ignoreTagsMap["defined_tags"])
+}
+
+func interfaceListToStrings(raw interface{}) []string {
+	var result []string
+	if list, ok := raw.([]interface{}); ok {
+		for _, item := range list {
+			if str, ok := item.(string); ok && str != "" {
+				result = append(result, str)
+			}
+		}
+	}
+	return result
+}
+
+// RetryConfigurations returns the retry behavior from the retry blocks, keyed by normalized service name
+func RetryConfigurations(d schemaResourceData) (map[string]*tf_resource.RetryConfiguration, error) {
+	retryBlocks, ok := d.GetOkExists(globalvar.RetryAttrName)
+	if !ok {
+		return nil, nil
+	}
+	retryList, ok := retryBlocks.([]interface{})
+	if !ok || len(retryList) == 0 {
+		return nil, nil
+	}
+
+	retryConfigurations := make(map[string]*tf_resource.RetryConfiguration, len(retryList))
+	for _, item := range retryList {
+		retryBlock, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		service, _ := retryBlock["service"].(string)
+		serviceKey := tf_resource.NormalizeRetryServiceName(service)
+		if _, exists := retryConfigurations[serviceKey]; exists {
+			return nil, fmt.Errorf("%s block for service '%s' is specified more than once", globalvar.RetryAttrName, service)
+		}
+
+		retryConfiguration := &tf_resource.RetryConfiguration{}
+		if maxDurationSeconds, ok := retryBlock["max_duration_seconds"].(int); ok && maxDurationSeconds >= 0 {
+			maxDuration := time.Duration(maxDurationSeconds) * time.Second
+			retryConfiguration.MaxDuration = &maxDuration
+		}
+		if backoffBaseSeconds, ok := retryBlock["backoff_base_seconds"].(int); ok {
+			retryConfiguration.BackoffBase = time.Duration(backoffBaseSeconds) * time.Second
+		}
+		if backoffCapSeconds, ok := retryBlock["backoff_cap_seconds"].(int); ok {
+			retryConfiguration.BackoffCap = time.Duration(backoffCapSeconds) * time.Second
+		}
+		if jitter, ok := retryBlock["jitter"].(bool); ok {
+			retryConfiguration.Jitter = jitter
+		}
+		if statusCodes, ok := retryBlock["retryable_status_codes"].([]interface{}); ok {
+			for _, statusCode := range statusCodes {
+				if code, ok := statusCode.(int); ok {
+					retryConfiguration.RetryableStatusCodes = append(retryConfiguration.RetryableStatusCodes, code)
+				}
+			}
+		}
+		retryConfiguration.RetryableServiceCodes = interfaceListToStrings(retryBlock["retryable_service_codes"])
+
+		retryConfigurations[serviceKey] = retryConfiguration
+	}
+	return retryConfigurations, nil
+}
+
+// RateLimitConfigurations returns the rate_limit blocks keyed by registered client name
+func RateLimitConfigurations(d schemaResourceData) (map[string]tf_client.RateLimitConfiguration, error) {
+	rateLimitBlocks, ok := d.GetOkExists(globalvar.RateLimitAttrName)
+	if !ok {
+		return nil, nil
+	}
+	rateLimitList, ok := rateLimitBlocks.([]interface{})
+	if !ok || len(rateLimitList) == 0 {
+		return nil, nil
+	}
+
+	rateLimitConfigurations := make(map[string]tf_client.RateLimitConfiguration, len(rateLimitList))
+	for _, item := range rateLimitList {
+		rateLimitBlock, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		clientName, _ := rateLimitBlock["client"].(string)
+		if tf_client.OracleClientRegistrationsVar != nil {
+			if _, registered := tf_client.OracleClientRegistrationsVar.RegisteredClients[clientName]; !registered {
+				return nil, fmt.Errorf("%s block refers to an unknown client '%s'", globalvar.RateLimitAttrName, clientName)
+			}
+		}
+		if _, exists := rateLimitConfigurations[clientName]; exists {
+			return nil, fmt.Errorf("%s block for client '%s' is specified more than once", globalvar.RateLimitAttrName, clientName)
+		}
+
+		rateLimitConfiguration := tf_client.RateLimitConfiguration{}
+		if requestsPerSecond, ok := rateLimitBlock["requests_per_second"].(float64); ok {
+			rateLimitConfiguration.RequestsPerSecond = requestsPerSecond
+		}
+		if burst, ok := rateLimitBlock["burst"].(int); ok {
+			rateLimitConfiguration.Burst = burst
+		}
+		if maxConcurrentRequests, ok := rateLimitBlock["max_concurrent_requests"].(int); ok {
+			rateLimitConfiguration.MaxConcurrentRequests = maxConcurrentRequests
+		}
+		rateLimitConfigurations[clientName] = rateLimitConfiguration
+	}
+	return rateLimitConfigurations, nil
+}
+
+// DefaultTags returns the provider level default tags configured under attrName, if any
+func DefaultTags(d schemaResourceData, attrName string) map[string]interface{} {
+	if defaultTags, ok := d.GetOkExists(attrName); ok {
+		if tags, ok := defaultTags.(map[string]interface{}); ok && len(tags) > 0 {
+			return tags
+		}
+	}
+	return nil
+}
+
 func realmSpecificServiceEndpointTemplateEnabled(d schemaResourceData) string {
 	if flag, ok := d.GetOkExists(globalvar.RealmSpecificServiceEndpointTemplateEnabled); ok {
 		return strconv.FormatBool(flag.(bool))
@@ -626,6 +1051,12 @@ func BuildConfigureClientFn(configProvider oci_common.ConfigurationProvider, htt
 		requestSigner = oci_common.RequestSigner(configProvider, httpHeadersToSign, oci_common.DefaultBodyHeaders())
 		oboTokenProvider = oboTokenProviderFromEnv{}
 	}
+	if assumedIdentityOboTokenProvider, ok := configProvider.(OboTokenProvider); ok {
+		// The token of an assumed identity takes precedence over the one from the environment
+		httpHeadersToSign := append(oci_common.DefaultGenericHeaders(), globalvar.RequestHeaderOpcOboToken)
+		requestSigner = oci_common.RequestSigner(configProvider, httpHeadersToSign, oci_common.DefaultBodyHeaders())
+		oboTokenProvider = assumedIdentityOboTokenProvider
+	}
 
 	configureClientFn := func(client *oci_common.BaseClient) error {
 		client.HTTPClient = httpClient
@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path"
 	"reflect"
 	"strings"
 	"testing"
@@ -19,6 +20,7 @@ import (
 	"github.com/oracle/terraform-provider-oci/httpreplay"
 	tf_client "github.com/oracle/terraform-provider-oci/internal/client"
 	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+	tf_resource "github.com/oracle/terraform-provider-oci/internal/tfresource"
 	"github.com/oracle/terraform-provider-oci/internal/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -755,6 +757,241 @@ func TestUnitIgnoreTags(t *testing.T) {
 
 }
 
+type mockRetryResourceData struct {
+	retry []interface{}
+}
+
+func (d *mockRetryResourceData) GetOkExists(_ string) (interface{}, bool) {
+	return d.retry, d.retry != nil
+}
+
+func TestUnitRetryConfigurations(t *testing.T) {
+	retryConfigurations, err := RetryConfigurations(&mockRetryResourceData{})
+	assert.NoError(t, err)
+	assert.Nil(t, retryConfigurations)
+
+	identityRetryBlock := map[string]interface{}{
+		"service":                 "identity",
+		"max_duration_seconds":    1200,
+		"backoff_base_seconds":    2,
+		"backoff_cap_seconds":     120,
+		"jitter":                  true,
+		"retryable_status_codes":  []interface{}{429},
+		"retryable_service_codes": []interface{}{"TooManyRequests"},
+	}
+	objectStorageRetryBlock := map[string]interface{}{
+		"service":              "objectstorage",
+		"max_duration_seconds": -1,
+		"backoff_base_seconds": 1,
+		"backoff_cap_seconds":  288,
+		"jitter":               false,
+	}
+	retryConfigurations, err = RetryConfigurations(&mockRetryResourceData{retry: []interface{}{identityRetryBlock, objectStorageRetryBlock}})
+	assert.NoError(t, err)
+
+	identityMaxDuration := 20 * time.Minute
+	assert.Equal(t, &tf_resource.RetryConfiguration{
+		MaxDuration:           &identityMaxDuration,
+		BackoffBase:           2 * time.Second,
+		BackoffCap:            2 * time.Minute,
+		Jitter:                true,
+		RetryableStatusCodes:  []int{429},
+		RetryableServiceCodes: []string{"TooManyRequests"},
+	}, retryConfigurations["identity"])
+	assert.Nil(t, retryConfigurations[tf_resource.NormalizeRetryServiceName("object_storage")].MaxDuration)
+
+	_, err = RetryConfigurations(&mockRetryResourceData{retry: []interface{}{identityRetryBlock, identityRetryBlock}})
+	assert.Error(t, err)
+}
+
+type mockRateLimitResourceData struct {
+	rateLimit []interface{}
+}
+
+func (d *mockRateLimitResourceData) GetOkExists(_ string) (interface{}, bool) {
+	return d.rateLimit, d.rateLimit != nil
+}
+
+func TestUnitRateLimitConfigurations(t *testing.T) {
+	rateLimitConfigurations, err := RateLimitConfigurations(&mockRateLimitResourceData{})
+	assert.NoError(t, err)
+	assert.Nil(t, rateLimitConfigurations)
+
+	identityRateLimitBlock := map[string]interface{}{
+		"client":                  "oci_identity.IdentityClient",
+		"requests_per_second":     2.5,
+		"burst":                   5,
+		"max_concurrent_requests": 4,
+	}
+	rateLimitConfigurations, err = RateLimitConfigurations(&mockRateLimitResourceData{rateLimit: []interface{}{identityRateLimitBlock}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]tf_client.RateLimitConfiguration{
+		"oci_identity.IdentityClient": {RequestsPerSecond: 2.5, Burst: 5, MaxConcurrentRequests: 4},
+	}, rateLimitConfigurations)
+
+	_, err = RateLimitConfigurations(&mockRateLimitResourceData{rateLimit: []interface{}{identityRateLimitBlock, identityRateLimitBlock}})
+	assert.Error(t, err)
+
+	_, err = RateLimitConfigurations(&mockRateLimitResourceData{rateLimit: []interface{}{map[string]interface{}{"client": "oci_unknown.UnknownClient"}}})
+	assert.Error(t, err)
+}
+
+type mockAssumeResourceData struct {
+	assume []interface{}
+}
+
+func (d *mockAssumeResourceData) GetOkExists(_ string) (interface{}, bool) {
+	return d.assume, d.assume != nil
+}
+
+func TestUnitAssumedIdentityConfigProvider(t *testing.T) {
+	baseConfigProvider := oci_common.NewRawConfigurationProvider("ocid1.tenancy.oc1..base", "ocid1.user.oc1..base", "us-phoenix-1", "fingerprint", "", nil)
+
+	configProvider, err := getAssumedIdentityConfigProvider(&mockAssumeResourceData{}, baseConfigProvider)
+	assert.NoError(t, err)
+	assert.Equal(t, baseConfigProvider, configProvider)
+
+	_, err = getAssumedIdentityConfigProvider(&mockAssumeResourceData{assume: []interface{}{map[string]interface{}{
+		globalvar.TenancyOcidAttrName: "ocid1.tenancy.oc1..target",
+	}}}, baseConfigProvider)
+	assert.Error(t, err)
+
+	configProvider, err = getAssumedIdentityConfigProvider(&mockAssumeResourceData{assume: []interface{}{map[string]interface{}{
+		globalvar.TenancyOcidAttrName: "ocid1.tenancy.oc1..target",
+		globalvar.OboTokenAttrName:    "fake-token",
+	}}}, baseConfigProvider)
+	assert.NoError(t, err)
+
+	tenancy, _ := configProvider.TenancyOCID()
+	assert.Equal(t, "ocid1.tenancy.oc1..target", tenancy)
+	region, _ := configProvider.Region()
+	assert.Equal(t, "us-phoenix-1", region)
+	user, _ := configProvider.UserOCID()
+	assert.Equal(t, "ocid1.user.oc1..base", user)
+
+	configureClientFn, err := BuildConfigureClientFn(configProvider, BuildHttpClient())
+	assert.NoError(t, err)
+	baseClient := &oci_common.BaseClient{}
+	assert.NoError(t, configureClientFn(baseClient))
+	r, _ := http.NewRequest("GET", "cloud.com", nil)
+	baseClient.Interceptor(r)
+	assert.Equal(t, "fake-token", r.Header.Get(globalvar.RequestHeaderOpcOboToken))
+}
+
+func TestUnitAssumedIdentityConfigProvider_tokenFromFile(t *testing.T) {
+	tokenPath := path.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("fake-token\n"), 0600))
+
+	baseConfigProvider := oci_common.NewRawConfigurationProvider("ocid1.tenancy.oc1..base", "ocid1.user.oc1..base", "us-phoenix-1", "fingerprint", "", nil)
+	configProvider, err := getAssumedIdentityConfigProvider(&mockAssumeResourceData{assume: []interface{}{map[string]interface{}{
+		globalvar.RegionAttrName: "us-ashburn-1",
+		globalvar.OboTokenPath:   tokenPath,
+	}}}, baseConfigProvider)
+	assert.NoError(t, err)
+
+	region, _ := configProvider.Region()
+	assert.Equal(t, "us-ashburn-1", region)
+
+	oboTokenProvider, ok := configProvider.(OboTokenProvider)
+	assert.True(t, ok)
+	token, err := oboTokenProvider.OboToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "fake-token", token)
+
+	// A refreshed token is picked up once the file changes
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("another-token"), 0600))
+	assert.NoError(t, os.Chtimes(tokenPath, time.Now(), time.Now().Add(time.Minute)))
+	token, err = oboTokenProvider.OboToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "another-token", token)
+}
+
+type mockIgnoreTagsResourceData struct {
+	ignoreTags []interface{}
+}
+
+func (d *mockIgnoreTagsResourceData) GetOkExists(_ string) (interface{}, bool) {
+	return d.ignoreTags, d.ignoreTags != nil
+}
+
+func TestUnitIgnoreTagsBlock(t *testing.T) {
+	tests := []struct {
+		name                string
+		d                   *mockIgnoreTagsResourceData
+		expectedFreeformTag []string
+		expectedDefinedTag  []string
+	}{
+		{
+			name: "Test ignore_tags is set",
+			d: &mockIgnoreTagsResourceData{ignoreTags: []interface{}{map[string]interface{}{
+				"freeform_tags": []interface{}{"ops-*"},
+				"defined_tags":  []interface{}{"Oracle-Tags.CreatedBy", "Operations.*"},
+			}}},
+			expectedFreeformTag: []string{"ops-*"},
+			expectedDefinedTag:  []string{"Oracle-Tags.CreatedBy", "Operations.*"},
+		},
+		{
+			name: "Test ignore_tags only has defined tags",
+			d: &mockIgnoreTagsResourceData{ignoreTags: []interface{}{map[string]interface{}{
+				"defined_tags": []interface{}{"Operations.*"},
+			}}},
+			expectedFreeformTag: nil,
+			expectedDefinedTag:  []string{"Operations.*"},
+		},
+		{
+			name: "Test ignore_tags is not set",
+			d:    &mockIgnoreTagsResourceData{},
+		},
+	}
+	for _, test := range tests {
+		t.Logf("Running %s", test.name)
+		freeformTags, definedTags := IgnoreTags(test.d)
+		assert.Equal(t, test.expectedFreeformTag, freeformTags)
+		assert.Equal(t, test.expectedDefinedTag, definedTags)
+	}
+}
+
+type mockDefaultTagsResourceData struct {
+	tags map[string]interface{}
+}
+
+func (d *mockDefaultTagsResourceData) GetOkExists(_ string) (interface{}, bool) {
+	if d.tags == nil {
+		return nil, false
+	}
+	return d.tags, true
+}
+
+func TestUnitDefaultTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		d      *mockDefaultTagsResourceData
+		output map[string]interface{}
+	}{
+		{
+			name:   "Test default tags are set",
+			d:      &mockDefaultTagsResourceData{tags: map[string]interface{}{"CostCenter": "42"}},
+			output: map[string]interface{}{"CostCenter": "42"},
+		},
+		{
+			name:   "Test default tags are empty",
+			d:      &mockDefaultTagsResourceData{tags: map[string]interface{}{}},
+			output: nil,
+		},
+		{
+			name:   "Test default tags are not set",
+			d:      &mockDefaultTagsResourceData{},
+			output: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Logf("Running %s", test.name)
+		if res := DefaultTags(test.d, globalvar.DefaultFreeformTagsAttrName); !reflect.DeepEqual(res, test.output) {
+			t.Errorf("Output map - %v which is not equal to expected map - %v", res, test.output)
+		}
+	}
+}
+
 func TestUnit_RegisterResourceMap(t *testing.T) {
 	tests := []struct {
 		name string
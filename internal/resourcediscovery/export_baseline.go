@@ -0,0 +1,287 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+	"github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+// baselineStateFile is the subset of a Terraform v4 state file read to compare a new export with a previous one
+type baselineStateFile struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Module    string `json:"module,omitempty"`
+		Instances []struct {
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+type baselineResource struct {
+	Type       string
+	Name       string
+	Module     string
+	Attributes map[string]interface{}
+}
+
+func (b *baselineResource) getTerraformReference() string {
+	return fmt.Sprintf("%s.%s", b.Type, b.Name)
+}
+
+// DriftReport lists the changes between the resources of a previous export and the resources discovered now
+type DriftReport struct {
+	BaselineState string          `json:"baseline_state"`
+	Added         []DriftResource `json:"added"`
+	Removed       []DriftResource `json:"removed"`
+	Changed       []DriftResource `json:"changed"`
+	Unchanged     int             `json:"unchanged"`
+}
+
+type DriftResource struct {
+	Id                string           `json:"id"`
+	Type              string           `json:"type"`
+	Name              string           `json:"name"`
+	CompartmentId     string           `json:"compartment_id,omitempty"`
+	ChangedAttributes []DriftAttribute `json:"changed_attributes,omitempty"`
+}
+
+type DriftAttribute struct {
+	Name       string      `json:"name"`
+	Baseline   interface{} `json:"baseline"`
+	Discovered interface{} `json:"discovered"`
+}
+
+// loadBaselineState reads the managed resources of a previous export by their ID
+func loadBaselineState(baselineStatePath string) (map[string]*baselineResource, error) {
+	stateBytes, err := ioutil.ReadFile(baselineStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] unable to read baseline state %s: %v", baselineStatePath, err)
+	}
+	var state baselineStateFile
+	if err := json.Unmarshal(stateBytes, &state); err != nil {
+		return nil, fmt.Errorf("[ERROR] unable to parse baseline state %s: %v", baselineStatePath, err)
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("[ERROR] unsupported baseline state version %d in %s, only version 4 is supported", state.Version, baselineStatePath)
+	}
+
+	result := map[string]*baselineResource{}
+	for _, resource := range state.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+		for _, instance := range resource.Instances {
+			id, _ := instance.Attributes["id"].(string)
+			if id == "" {
+				continue
+			}
+			result[id] = &baselineResource{
+				Type:       resource.Type,
+				Name:       resource.Name,
+				Module:     resource.Module,
+				Attributes: instance.Attributes,
+			}
+		}
+	}
+	return result, nil
+}
+
+// compareWithBaseline compares the discovered resources with the resources of the baseline state. Only the resources that
+// are not in the baseline are kept in the steps, the references to the other resources use their address in the baseline.
+func compareWithBaseline(ctx *tf_export.ResourceDiscoveryContext, steps []resourceDiscoveryStep, baseline map[string]*baselineResource) *DriftReport {
+	report := &DriftReport{
+		BaselineState: *ctx.BaselineState,
+		Added:         []DriftResource{},
+		Removed:       []DriftResource{},
+		Changed:       []DriftResource{},
+	}
+	discoveredIds := map[string]bool{}
+
+	for _, step := range steps {
+		baseStep := step.getBaseStep()
+		newResources := make([]*tf_export.OCIResource, 0, len(baseStep.discoveredResources))
+		for _, resource := range baseStep.discoveredResources {
+			if resource.TerraformTypeInfo != nil && resource.TerraformTypeInfo.IsDataSource {
+				newResources = append(newResources, resource)
+				continue
+			}
+			discoveredIds[resource.Id] = true
+
+			baselineResource, exists := baseline[resource.Id]
+			if !exists || baselineResource.Type != resource.TerraformClass {
+				report.Added = append(report.Added, newDriftResource(resource.Id, resource.TerraformClass, resource.TerraformName, resource.CompartmentId))
+				newResources = append(newResources, resource)
+				continue
+			}
+
+			if changedAttributes := getChangedAttributes(resource, baselineResource); len(changedAttributes) > 0 {
+				changed := newDriftResource(resource.Id, baselineResource.Type, baselineResource.Name, resource.CompartmentId)
+				changed.ChangedAttributes = changedAttributes
+				report.Changed = append(report.Changed, changed)
+			} else {
+				report.Unchanged++
+			}
+
+			useBaselineReference(resource, baselineResource)
+		}
+		baseStep.discoveredResources = newResources
+	}
+
+	for id, baselineResource := range baseline {
+		if !discoveredIds[id] {
+			report.Removed = append(report.Removed, newDriftResource(id, baselineResource.Type, baselineResource.Name, ""))
+		}
+	}
+
+	sortDriftResources(report.Added)
+	sortDriftResources(report.Removed)
+	sortDriftResources(report.Changed)
+	return report
+}
+
+// reserveBaselineNames keeps the names of the resources of the baseline from being given to new resources
+func reserveBaselineNames(baseline map[string]*baselineResource) {
+	tf_export.ResourceNameCountLock.Lock()
+	defer tf_export.ResourceNameCountLock.Unlock()
+	for _, baselineResource := range baseline {
+		if _, exists := tf_export.ResourceNameCount[baselineResource.Name]; !exists {
+			tf_export.ResourceNameCount[baselineResource.Name] = 1
+		}
+	}
+}
+
+// useBaselineReference makes the new resources reference a resource of the previous export with its address in the baseline.
+// The references are replaced with hard coded values if the resource is in a module of the baseline.
+func useBaselineReference(resource *tf_export.OCIResource, baselineResource *baselineResource) {
+	discoveredReference := resource.GetTerraformReference()
+	if discoveredReference == baselineResource.getTerraformReference() && baselineResource.Module == "" {
+		return
+	}
+
+	// lock not required for referenceMap as only 1 thread is running at this point
+	for key, reference := range tf_export.ReferenceMap {
+		if !strings.Contains(reference, discoveredReference+".") {
+			continue
+		}
+		if baselineResource.Module != "" {
+			delete(tf_export.ReferenceMap, key)
+		} else {
+			tf_export.ReferenceMap[key] = strings.ReplaceAll(reference, discoveredReference+".", baselineResource.getTerraformReference()+".")
+		}
+	}
+
+	// interpolations to the resource in the discovered attributes of other resources are written as hard coded values
+	if tf_export.FailedResourceReferenceSet == nil {
+		tf_export.FailedResourceReferenceSet = make(map[string]bool)
+	}
+	tf_export.FailedResourceReferenceSet[discoveredReference] = true
+}
+
+func newDriftResource(id string, resourceType string, name string, compartmentId string) DriftResource {
+	return DriftResource{Id: id, Type: resourceType, Name: name, CompartmentId: compartmentId}
+}
+
+func sortDriftResources(resources []DriftResource) {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].Id < resources[j].Id
+	})
+}
+
+// getChangedAttributes compares the configurable attributes of the discovered resource with the attributes of the baseline
+func getChangedAttributes(resource *tf_export.OCIResource, baselineResource *baselineResource) []DriftAttribute {
+	resourceSchema, exists := tf_export.ResourcesMap[resource.TerraformClass]
+	if !exists {
+		return nil
+	}
+
+	var changedAttributes []DriftAttribute
+	for attribute, attributeSchema := range resourceSchema.Schema {
+		if attributeSchema.Deprecated != "" || (!attributeSchema.Required && !attributeSchema.Optional) {
+			continue
+		}
+		discovered, discoveredExists := resource.SourceAttributes[attribute]
+		if !discoveredExists {
+			// the attribute is not returned by the service, e.g. sensitive values
+			continue
+		}
+		discovered = normalizeDriftValue(discovered)
+		baseline := normalizeDriftValue(baselineResource.Attributes[attribute])
+		if !reflect.DeepEqual(discovered, baseline) {
+			changedAttributes = append(changedAttributes, DriftAttribute{Name: attribute, Baseline: baseline, Discovered: discovered})
+		}
+	}
+
+	sort.Slice(changedAttributes, func(i, j int) bool {
+		return changedAttributes[i].Name < changedAttributes[j].Name
+	})
+	return changedAttributes
+}
+
+// normalizeDriftValue converts a value to its JSON representation so that discovered values compare with the values read from the state,
+// empty values are treated as not set
+func normalizeDriftValue(value interface{}) interface{} {
+	if interpolation, ok := value.(tf_export.InterpolationString); ok {
+		value = interpolation.Value
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var result interface{}
+	if err := json.Unmarshal(valueBytes, &result); err != nil {
+		return value
+	}
+	switch v := result.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return result
+}
+
+// writeDriftReport writes the report under the output path and adds its summary
+func writeDriftReport(ctx *tf_export.ResourceDiscoveryContext, report *DriftReport) error {
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	reportFile := fmt.Sprintf("%s%s%s", *ctx.OutputDir, string(os.PathSeparator), globalvar.DriftReportFile)
+	if err := ioutil.WriteFile(reportFile, reportBytes, 0644); err != nil {
+		return fmt.Errorf("[ERROR] error writing drift report at %s: %s", reportFile, err.Error())
+	}
+	utils.Logf("[INFO] drift report written to file at: %s", reportFile)
+
+	ctx.SummaryStatements = append(ctx.SummaryStatements, "")
+	ctx.SummaryStatements = append(ctx.SummaryStatements, fmt.Sprintf("Compared with baseline state '%s': %d added, %d removed, %d changed, %d unchanged resources. Report written to '%s'",
+		report.BaselineState, len(report.Added), len(report.Removed), len(report.Changed), report.Unchanged, reportFile))
+	for _, removed := range report.Removed {
+		ctx.SummaryStatements = append(ctx.SummaryStatements, fmt.Sprintf("- removed %s.%s (%s)", removed.Type, removed.Name, removed.Id))
+	}
+	return nil
+}
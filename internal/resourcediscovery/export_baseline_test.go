@@ -0,0 +1,149 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+)
+
+const testBaselineState = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "oci_test_parent",
+      "name": "parent_0",
+      "instances": [{"attributes": {"id": "ocid1.parent.0", "display_name": "parent", "a_int": 1, "a_map": {}}}]
+    },
+    {
+      "mode": "managed",
+      "type": "oci_test_parent",
+      "name": "parent_1",
+      "instances": [{"attributes": {"id": "ocid1.parent.1", "display_name": "before", "a_int": 2}}]
+    },
+    {
+      "mode": "managed",
+      "type": "oci_test_parent",
+      "name": "parent_deleted",
+      "instances": [{"attributes": {"id": "ocid1.parent.deleted", "display_name": "deleted"}}]
+    },
+    {
+      "mode": "data",
+      "type": "oci_identity_availability_domains",
+      "name": "ads",
+      "instances": [{"attributes": {"id": "ads"}}]
+    }
+  ]
+}`
+
+func writeTestBaselineState(t *testing.T, state string) string {
+	baselineStatePath := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(baselineStatePath, []byte(state), 0644); err != nil {
+		t.Fatalf("unable to write baseline state: %v", err)
+	}
+	return baselineStatePath
+}
+
+func TestUnitLoadBaselineState(t *testing.T) {
+	baseline, err := loadBaselineState(writeTestBaselineState(t, testBaselineState))
+	assert.NoError(t, err)
+	assert.Len(t, baseline, 3)
+	if assert.Contains(t, baseline, "ocid1.parent.1") {
+		assert.Equal(t, "oci_test_parent.parent_1", baseline["ocid1.parent.1"].getTerraformReference())
+	}
+
+	_, err = loadBaselineState(writeTestBaselineState(t, `{"version": 3}`))
+	assert.Error(t, err)
+	_, err = loadBaselineState(filepath.Join(t.TempDir(), "missing.tfstate"))
+	assert.Error(t, err)
+}
+
+func TestUnitCompareWithBaseline(t *testing.T) {
+	initResourceDiscoveryTests()
+	defer cleanupResourceDiscoveryTests()
+	referenceMap := tf_export.ReferenceMap
+	defer func() { tf_export.ReferenceMap = referenceMap }()
+	failedResourceReferenceSet := tf_export.FailedResourceReferenceSet
+	defer func() { tf_export.FailedResourceReferenceSet = failedResourceReferenceSet }()
+
+	baselineStatePath := writeTestBaselineState(t, testBaselineState)
+	outputDir := t.TempDir()
+	ctx := &tf_export.ResourceDiscoveryContext{
+		ExportCommandArgs: &tf_export.ExportCommandArgs{OutputDir: &outputDir, BaselineState: &baselineStatePath},
+	}
+	newResource := &tf_export.OCIResource{
+		SourceAttributes:  map[string]interface{}{"display_name": "new"},
+		TerraformResource: tf_export.TerraformResource{Id: "ocid1.parent.new", TerraformClass: "oci_test_parent", TerraformName: "parent_new"},
+	}
+	step := &resourceDiscoveryWithGraph{
+		resourceDiscoveryBaseStep: resourceDiscoveryBaseStep{
+			ctx: ctx,
+			discoveredResources: []*tf_export.OCIResource{
+				{
+					SourceAttributes:  map[string]interface{}{"display_name": "parent", "a_int": 1},
+					TerraformResource: tf_export.TerraformResource{Id: "ocid1.parent.0", TerraformClass: "oci_test_parent", TerraformName: "parent_0"},
+				},
+				{
+					SourceAttributes:  map[string]interface{}{"display_name": "after", "a_int": 2},
+					TerraformResource: tf_export.TerraformResource{Id: "ocid1.parent.1", TerraformClass: "oci_test_parent", TerraformName: "parent_1_1"},
+				},
+				newResource,
+			},
+		},
+	}
+	tf_export.ReferenceMap = map[string]string{
+		"ocid1.parent.0":   "oci_test_parent.parent_0.id",
+		"ocid1.parent.1":   "oci_test_parent.parent_1_1.id",
+		"ocid1.parent.new": "oci_test_parent.parent_new.id",
+	}
+
+	baseline, err := loadBaselineState(baselineStatePath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	report := compareWithBaseline(ctx, []resourceDiscoveryStep{step}, baseline)
+
+	assert.Equal(t, []*tf_export.OCIResource{newResource}, step.getDiscoveredResources())
+	assert.Equal(t, 1, report.Unchanged)
+	if assert.Len(t, report.Added, 1) {
+		assert.Equal(t, "ocid1.parent.new", report.Added[0].Id)
+	}
+	if assert.Len(t, report.Removed, 1) {
+		assert.Equal(t, "parent_deleted", report.Removed[0].Name)
+	}
+	if assert.Len(t, report.Changed, 1) {
+		assert.Equal(t, "parent_1", report.Changed[0].Name)
+		assert.Equal(t, []DriftAttribute{{Name: "display_name", Baseline: "before", Discovered: "after"}}, report.Changed[0].ChangedAttributes)
+	}
+
+	// references to existing resources use their address in the baseline
+	assert.Equal(t, "oci_test_parent.parent_0.id", tf_export.ReferenceMap["ocid1.parent.0"])
+	assert.Equal(t, "oci_test_parent.parent_1.id", tf_export.ReferenceMap["ocid1.parent.1"])
+	assert.True(t, tf_export.FailedResourceReferenceSet["oci_test_parent.parent_1_1"])
+
+	assert.NoError(t, writeDriftReport(ctx, report))
+	reportBytes, err := os.ReadFile(filepath.Join(outputDir, "drift_report.json"))
+	if assert.NoError(t, err) {
+		var writtenReport DriftReport
+		assert.NoError(t, json.Unmarshal(reportBytes, &writtenReport))
+		assert.Equal(t, baselineStatePath, writtenReport.BaselineState)
+		assert.Len(t, writtenReport.Changed, 1)
+	}
+}
+
+func TestUnitReserveBaselineNames(t *testing.T) {
+	resourceNameCount := tf_export.ResourceNameCount
+	defer func() { tf_export.ResourceNameCount = resourceNameCount }()
+	tf_export.ResourceNameCount = map[string]int{}
+
+	reserveBaselineNames(map[string]*baselineResource{"ocid1.vcn": {Type: "oci_core_vcn", Name: "export_vcn"}})
+	assert.Equal(t, "export_vcn_1", tf_export.CheckDuplicateResourceName("export_vcn"))
+}
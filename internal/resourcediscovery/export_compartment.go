@@ -111,6 +111,7 @@ func elapsed(what string, step *resourceDiscoveryBaseStep, stage ResourceDiscove
 func init() {
 	tf_export.ResourceNameCount = map[string]int{}
 	tf_export.Vars = map[string]string{}
+	tf_export.SensitiveVars = map[string]bool{}
 	tf_export.ReferenceMap = map[string]string{}
 	tf_export.VarsExportForResourceLevel = map[string][]string{}
 	tf_export.VarsExportForGlobalLevel = []string{}
@@ -227,6 +228,15 @@ func RunListExportableServicesCommand(listExportServicesPath string) error {
 }
 
 func RunExportCommand(args *tf_export.ExportCommandArgs) (err error, status Status) {
+	var ctx *tf_export.ResourceDiscoveryContext
+	// registered first so that the report has the status set when recovering from a panic
+	defer func() {
+		if args != nil && args.ReportPath != nil && *args.ReportPath != "" {
+			if reportErr := writeExportReport(args, ctx, err, status); reportErr != nil {
+				utils.Logln(reportErr.Error())
+			}
+		}
+	}()
 	defer func() {
 		if r := recover(); r != nil {
 			utils.Logf("[ERROR] panic in RunExportCommand, exiting with status %v", StatusFail)
@@ -261,6 +271,19 @@ func RunExportCommand(args *tf_export.ExportCommandArgs) (err error, status Stat
 		return err, StatusFail
 	}
 
+	// the first of the compartments of export_config is the compartment of the export
+	for i, compartment := range args.Compartments {
+		if compartment.CompartmentName != nil && *compartment.CompartmentName != "" {
+			if args.Compartments[i].CompartmentId, err = resolveCompartmentId(clients.(*tf_client.OracleClients), compartment.CompartmentName); err != nil {
+				utils.Logln(err.Error())
+				return err, StatusFail
+			}
+		}
+		if i == 0 {
+			args.CompartmentId = args.Compartments[i].CompartmentId
+		}
+	}
+
 	if args.CompartmentName != nil && *args.CompartmentName != "" {
 		var err error
 		args.CompartmentId, err = resolveCompartmentId(clients.(*tf_client.OracleClients), args.CompartmentName)
@@ -303,7 +326,7 @@ func RunExportCommand(args *tf_export.ExportCommandArgs) (err error, status Stat
 	MaxParallelChunks = numCPU
 	utils.Debugf("[INFO] Setting MaxParalleFindResources=%d, MaxParallelChunks=%d", MaxParallelFindResource, MaxParallelChunks)
 
-	ctx, err := createResourceDiscoveryContext(clients.(*tf_client.OracleClients), args, tenancyOcid)
+	ctx, err = createResourceDiscoveryContext(clients.(*tf_client.OracleClients), args, tenancyOcid)
 	ctx.Filters = args.Filters
 
 	if err != nil {
@@ -312,6 +335,13 @@ func RunExportCommand(args *tf_export.ExportCommandArgs) (err error, status Stat
 	}
 	args.FinalizeServices(ctx)
 
+	if len(args.Regions) > 0 {
+		if ctx.RegionClients, err = getRegionClients(d, args.Regions); err != nil {
+			utils.Logln(err.Error())
+			return err, StatusFail
+		}
+	}
+
 	/*
 		Setting retry timeout to a lower value for resource discovery
 		This is done to handle the 404 and 500 errors in case
@@ -403,10 +433,23 @@ func runExportCommand(ctx *tf_export.ResourceDiscoveryContext) error {
 	defer ctx.PrintSummary()
 	exportStart := time.Now()
 	defer elapsed("entire export command", nil, 0)()
+
+	var baseline map[string]*baselineResource
+	if ctx.BaselineState != nil && *ctx.BaselineState != "" {
+		var err error
+		if baseline, err = loadBaselineState(*ctx.BaselineState); err != nil {
+			return err
+		}
+		reserveBaselineNames(baseline)
+	}
+
 	steps, err := getDiscoverResourceSteps(ctx)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		ctx.StepReports = newExportStepReports(steps)
+	}()
 	discoveryStart := time.Now()
 	var discoverWg sync.WaitGroup
 	discoverWg.Add(len(steps))
@@ -459,8 +502,15 @@ func runExportCommand(ctx *tf_export.ResourceDiscoveryContext) error {
 	totalDiscoveryTime := time.Since(discoveryStart)
 	utils.Debugf("discovering resources for all services took %v\n", totalDiscoveryTime)
 	ctx.TimeTakenToDiscover = totalDiscoveryTime
+	useExportContext(ctx, steps)
 	utils.Debug("[DEBUG] ~~~~~~ discover steps completed ~~~~~~")
 
+	// Only the resources that are not in the previous export are generated
+	var driftReport *DriftReport
+	if baseline != nil {
+		driftReport = compareWithBaseline(ctx, steps, baseline)
+	}
+
 	if ctx.GenerateState {
 		stateStart := time.Now()
 		// Run import commands
@@ -489,6 +539,16 @@ func runExportCommand(ctx *tf_export.ResourceDiscoveryContext) error {
 	// Reset discovered resources if already set by writeTmpConfigurationForImport
 	ctx.DiscoveredResources = make([]*tf_export.OCIResource, 0)
 
+	var modules *exportModules
+	if ctx.OutputLayout == tf_export.OutputLayoutModules {
+		if modules, err = newExportModules(ctx, steps); err != nil {
+			return err
+		}
+		for _, step := range steps {
+			step.getBaseStep().modules = modules
+		}
+	}
+
 	/*
 		sem allows number of steps equals to arg.Parallelism to execute in parallel.
 		arg.Parallelism is very less compare to total number of steps
@@ -547,13 +607,22 @@ func runExportCommand(ctx *tf_export.ResourceDiscoveryContext) error {
 		return errs
 	}
 
-	region, err := exportConfigProvider.Region()
-	if err != nil {
+	var region string
+	if len(ctx.Regions) > 0 {
+		// the default provider is configured with the first region to export
+		region = ctx.Regions[0]
+	} else if region, err = exportConfigProvider.Region(); err != nil {
 		return err
 	}
 	tf_export.Vars["region"] = fmt.Sprintf("\"%s\"", region)
 
-	if err := generateProviderFile(ctx.OutputDir); err != nil {
+	if modules != nil {
+		if err := modules.writeModuleFiles(); err != nil {
+			return err
+		}
+	}
+
+	if err := generateProviderFile(ctx.OutputDir, ctx.Regions); err != nil {
 		return err
 	}
 
@@ -561,6 +630,17 @@ func runExportCommand(ctx *tf_export.ResourceDiscoveryContext) error {
 		return err
 	}
 
+	if driftReport != nil {
+		if err := writeDriftReport(ctx, driftReport); err != nil {
+			return err
+		}
+	}
+
+	if len(tf_export.SensitiveVars) > 0 {
+		ctx.SummaryStatements = append(ctx.SummaryStatements, "")
+		ctx.SummaryStatements = append(ctx.SummaryStatements, fmt.Sprintf("%d sensitive attributes were exported as variables without values in '%s', provide the values with a .tfvars file or TF_VAR_ environment variables", len(tf_export.SensitiveVars), globalvar.VarsFile))
+	}
+
 	if tf_export.IsMissingRequiredAttributes {
 		ctx.SummaryStatements = append(ctx.SummaryStatements, "")
 		ctx.SummaryStatements = append(ctx.SummaryStatements, globalvar.MissingRequiredAttributeWarning)
@@ -852,53 +932,64 @@ func getDiscoverResourceWithGraphSteps(ctx *tf_export.ResourceDiscoveryContext)
 	}
 	var result []resourceDiscoveryStep
 
-	// Discover tenancy scope resources only if compartmentId is tenancy ocid
-	if *ctx.CompartmentId == ctx.TenancyOcid {
-		tenancyResource := &tf_export.OCIResource{
-			CompartmentId: ctx.TenancyOcid,
+	scopes, err := getExportScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) > 0 && scopes[0].compartmentVar != "compartment_ocid" {
+		// each compartment has its own variable
+		delete(tf_export.Vars, "compartment_ocid")
+	}
+
+	for _, scope := range scopes {
+		// Discover tenancy scope resources only if compartmentId is tenancy ocid
+		if scope.isHomeScope && scope.compartmentId == ctx.TenancyOcid {
+			tenancyResource := &tf_export.OCIResource{
+				CompartmentId: ctx.TenancyOcid,
+				TerraformResource: tf_export.TerraformResource{
+					Id:             ctx.TenancyOcid,
+					TerraformClass: "oci_identity_tenancy",
+					TerraformName:  "export",
+				},
+			}
+
+			for _, mode := range ctx.Services {
+				if resourceGraph, exists := tf_export.TenancyResourceGraphs[mode]; exists {
+					result = append(result, &resourceDiscoveryWithGraph{
+						root:                      tenancyResource,
+						resourceGraph:             resourceGraph,
+						resourceDiscoveryBaseStep: resourceDiscoveryBaseStep{name: mode, ctx: scope.ctx},
+					})
+
+					tf_export.Vars["tenancy_ocid"] = fmt.Sprintf("\"%s\"", ctx.TenancyOcid)
+					tf_export.ReferenceMap[ctx.TenancyOcid] = tf_export.TfHclVersionvar.GetVarHclString("tenancy_ocid")
+				}
+			}
+		}
+
+		compartmentResource := &tf_export.OCIResource{
+			CompartmentId: scope.compartmentId,
 			TerraformResource: tf_export.TerraformResource{
-				Id:             ctx.TenancyOcid,
-				TerraformClass: "oci_identity_tenancy",
+				Id:             scope.compartmentId,
+				TerraformClass: "oci_identity_compartment",
 				TerraformName:  "export",
 			},
 		}
 
 		for _, mode := range ctx.Services {
-			if resourceGraph, exists := tf_export.TenancyResourceGraphs[mode]; exists {
+			if resourceGraph, exists := tf_export.CompartmentResourceGraphs[mode]; exists {
 				result = append(result, &resourceDiscoveryWithGraph{
-					root:                      tenancyResource,
+					root:                      compartmentResource,
 					resourceGraph:             resourceGraph,
-					resourceDiscoveryBaseStep: resourceDiscoveryBaseStep{name: mode, ctx: ctx},
+					resourceDiscoveryBaseStep: resourceDiscoveryBaseStep{name: mode + scope.stepSuffix, ctx: scope.ctx, providerAlias: scope.providerAlias},
 				})
 
-				tf_export.Vars["tenancy_ocid"] = fmt.Sprintf("\"%s\"", ctx.TenancyOcid)
-				tf_export.ReferenceMap[ctx.TenancyOcid] = tf_export.TfHclVersionvar.GetVarHclString("tenancy_ocid")
+				tf_export.Vars[scope.compartmentVar] = fmt.Sprintf("\"%s\"", scope.compartmentId)
+				tf_export.ReferenceMap[scope.compartmentId] = tf_export.TfHclVersionvar.GetVarHclString(scope.compartmentVar)
 			}
 		}
 	}
 
-	compartmentResource := &tf_export.OCIResource{
-		CompartmentId: *ctx.CompartmentId,
-		TerraformResource: tf_export.TerraformResource{
-			Id:             *ctx.CompartmentId,
-			TerraformClass: "oci_identity_compartment",
-			TerraformName:  "export",
-		},
-	}
-
-	for _, mode := range ctx.Services {
-		if resourceGraph, exists := tf_export.CompartmentResourceGraphs[mode]; exists {
-			result = append(result, &resourceDiscoveryWithGraph{
-				root:                      compartmentResource,
-				resourceGraph:             resourceGraph,
-				resourceDiscoveryBaseStep: resourceDiscoveryBaseStep{name: mode, ctx: ctx},
-			})
-
-			tf_export.Vars["compartment_ocid"] = fmt.Sprintf("\"%s\"", *ctx.CompartmentId)
-			tf_export.ReferenceMap[*ctx.CompartmentId] = tf_export.TfHclVersionvar.GetVarHclString("compartment_ocid")
-		}
-	}
-
 	return result, nil
 }
 
@@ -991,6 +1082,8 @@ func findResources(ctx *tf_export.ResourceDiscoveryContext, root *tf_export.OCIR
 				return
 			}
 
+			applyNamingTemplate(ctx, results)
+
 			if childType.ProcessDiscoveredResourcesFn != nil {
 				results, err = childType.ProcessDiscoveredResourcesFn(ctx, results)
 				if err != nil {
@@ -1069,7 +1162,14 @@ func generateVarsFile(vars map[string]string, outputDir *string) error {
 	}
 
 	for variable, defaultVal := range vars {
-		if defaultVal != "" {
+		if tf_export.SensitiveVars[variable] {
+			// sensitive variables are supported from Terraform v0.14, their values are provided with a .tfvars file or TF_VAR_ environment variables
+			if tf_export.TfHclVersionvar.ToString() == string(tf_export.TfVersion11) {
+				_, _ = file.WriteString(fmt.Sprintf("variable %s {}\n", variable))
+			} else {
+				_, _ = file.WriteString(fmt.Sprintf("variable %s { sensitive = true }\n", variable))
+			}
+		} else if defaultVal != "" {
 			_, _ = file.WriteString(fmt.Sprintf("variable %s { default = %s }\n", variable, defaultVal))
 		} else {
 			_, _ = file.WriteString(fmt.Sprintf("variable %s {}\n", variable))
@@ -1087,7 +1187,8 @@ func generateVarsFile(vars map[string]string, outputDir *string) error {
 	return nil
 }
 
-func generateProviderFile(outputDir *string) error {
+// generateProviderFile writes the default provider, and a provider with an alias for each of the other regions to export
+func generateProviderFile(outputDir *string, regions []string) error {
 	providerTmpFile := fmt.Sprintf("%s%s%s.tmp", *outputDir, string(os.PathSeparator), globalvar.ProviderFile)
 	providerOutputFile := fmt.Sprintf("%s%s%s", *outputDir, string(os.PathSeparator), globalvar.ProviderFile)
 	file, err := os.OpenFile(providerTmpFile, os.O_CREATE|os.O_RDWR, 0666)
@@ -1095,7 +1196,11 @@ func generateProviderFile(outputDir *string) error {
 		return err
 	}
 
-	_, err = file.WriteString(fmt.Sprintf("provider oci {\n\tregion = %s\n}\n", tf_export.TfHclVersionvar.GetVarHclString("region")))
+	providers := fmt.Sprintf("provider oci {\n\tregion = %s\n}\n", tf_export.TfHclVersionvar.GetVarHclString("region"))
+	for i := 1; i < len(regions); i++ {
+		providers += fmt.Sprintf("\nprovider oci {\n\talias  = \"%s\"\n\tregion = \"%s\"\n}\n", tf_export.GetProviderAlias(regions[i]), regions[i])
+	}
+	_, err = file.WriteString(providers)
 	if err != nil {
 		_ = file.Close()
 		return err
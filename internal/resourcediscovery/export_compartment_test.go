@@ -2881,6 +2881,27 @@ func TestUnitParseDeliveryPolicy(t *testing.T) {
 	assert.NotNil(t, tf_export.ParseDeliveryPolicy(policy))
 }
 
+func TestUnitGenerateVarsFileWithSensitiveVars(t *testing.T) {
+	tfHclVersion, sensitiveVars := tf_export.TfHclVersionvar, tf_export.SensitiveVars
+	defer func() { tf_export.TfHclVersionvar, tf_export.SensitiveVars = tfHclVersion, sensitiveVars }()
+	tf_export.SensitiveVars = map[string]bool{"oci_database_db--admin_password--export_db": true}
+	vars := map[string]string{"oci_database_db--admin_password--export_db": "", "region": "\"us-ashburn-1\""}
+
+	for version, expected := range map[tf_export.TfVersionEnum]string{
+		tf_export.TfVersion12: "variable oci_database_db--admin_password--export_db { sensitive = true }\n",
+		tf_export.TfVersion11: "variable oci_database_db--admin_password--export_db {}\n",
+	} {
+		tf_export.TfHclVersionvar, _ = tf_export.GetTfHclVersion(string(version))
+		outputDir := t.TempDir()
+		assert.NoError(t, generateVarsFile(vars, &outputDir))
+
+		content, err := os.ReadFile(path.Join(outputDir, globalvar.VarsFile))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), expected)
+		assert.Contains(t, string(content), "variable region { default = \"us-ashburn-1\" }\n")
+	}
+}
+
 /*
    func TestUnitDeleteInvalidReferencesWithReferenceResource(t *testing.T) {
    	t.Run("One Error resource with another reference resource", func(t *testing.T) {
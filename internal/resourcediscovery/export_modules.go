@@ -0,0 +1,295 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	oci_common "github.com/oracle/oci-go-sdk/v65/common"
+	oci_identity "github.com/oracle/oci-go-sdk/v65/identity"
+
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+	"github.com/oracle/terraform-provider-oci/internal/tfresource"
+	"github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+var (
+	// matches resource and data source references e.g. oci_core_vcn.export_vcn.id or data.oci_identity_availability_domains.ads.availability_domains
+	moduleReferenceRegex = regexp.MustCompile(`(data\.)?(oci_[a-z0-9_]+)\.([A-Za-z0-9_-]+)\.([A-Za-z0-9_]+)`)
+	moduleVariableRegex  = regexp.MustCompile(`var\.([A-Za-z0-9_-]+)`)
+	moduleNameRegex      = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+)
+
+// exportModule is the Terraform module generated for the resources of a compartment
+type exportModule struct {
+	name          string   // label of the module block in the root module
+	path          []string // directories of the module under modules, one for each compartment from the exported compartment
+	compartmentId string
+	variables     map[string]string // input variables of the module and the value passed by the root module
+	outputs       map[string]string // outputs of the module and their value
+	usedVars      map[string]bool   // variables of the root module referenced in the configuration of the module
+}
+
+// exportModules generates the configuration as one module per compartment, mirroring the compartment tree.
+// References to resources in other compartments are passed from the outputs of their module to input variables.
+type exportModules struct {
+	ctx             *tf_export.ResourceDiscoveryContext
+	modules         map[string]*exportModule // modules by compartment OCID
+	resourceModules map[string]*exportModule // modules by Terraform reference of the resources
+	moduleNames     map[string]bool
+	importBlocks    []string
+	lock            sync.Mutex
+}
+
+func (m *exportModule) dir(outputDir string) string {
+	return filepath.Join(append([]string{outputDir, globalvar.ModulesDir}, m.path...)...)
+}
+
+// newExportModules assigns the discovered resources of all steps to the module of their compartment
+func newExportModules(ctx *tf_export.ResourceDiscoveryContext, steps []resourceDiscoveryStep) (*exportModules, error) {
+	result := &exportModules{
+		ctx:             ctx,
+		modules:         map[string]*exportModule{},
+		resourceModules: map[string]*exportModule{},
+		moduleNames:     map[string]bool{},
+	}
+	compartmentPaths := map[string][]string{}
+
+	for _, step := range steps {
+		for _, resource := range step.getDiscoveredResources() {
+			compartmentId := resource.CompartmentId
+			if compartmentId == "" {
+				compartmentId = *ctx.CompartmentId
+			}
+
+			module, exists := result.modules[compartmentId]
+			if !exists {
+				path, err := getCompartmentPath(ctx, compartmentId, compartmentPaths)
+				if err != nil {
+					return nil, err
+				}
+				module = result.newModule(compartmentId, path)
+			}
+			result.resourceModules[getModuleResourceKey(resource)] = module
+		}
+	}
+	return result, nil
+}
+
+func (m *exportModules) newModule(compartmentId string, path []string) *exportModule {
+	baseName := strings.Join(path, "_")
+	if baseName == "" || (baseName[0] >= '0' && baseName[0] <= '9') {
+		baseName = "compartment_" + baseName
+	}
+	name := baseName
+	for i := 1; m.moduleNames[name]; i++ {
+		name = fmt.Sprintf("%s_%d", baseName, i)
+	}
+	m.moduleNames[name] = true
+
+	module := &exportModule{
+		name:          name,
+		path:          path,
+		compartmentId: compartmentId,
+		variables:     map[string]string{},
+		outputs:       map[string]string{},
+		usedVars:      map[string]bool{},
+	}
+	m.modules[compartmentId] = module
+	return module
+}
+
+// getCompartmentPath returns the names of the compartments from the exported compartment, or from the tenancy if the
+// compartment is not in the exported compartment, to the given compartment
+func getCompartmentPath(ctx *tf_export.ResourceDiscoveryContext, compartmentId string, compartmentPaths map[string][]string) ([]string, error) {
+	if path, exists := compartmentPaths[compartmentId]; exists {
+		return path, nil
+	}
+
+	response, err := identityClientGetCompartmentVar(ctx.Clients, oci_identity.GetCompartmentRequest{
+		CompartmentId: &compartmentId,
+		RequestMetadata: oci_common.RequestMetadata{
+			RetryPolicy: tfresource.GetRetryPolicy(true, "identity"),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] could not get the compartment %s to generate its module: %v", compartmentId, err)
+	}
+
+	name := compartmentId
+	if response.Name != nil {
+		name = *response.Name
+	}
+	path := []string{moduleNameRegex.ReplaceAllString(name, "_")}
+
+	if compartmentId != *ctx.CompartmentId && response.CompartmentId != nil {
+		parentPath, err := getCompartmentPath(ctx, *response.CompartmentId, compartmentPaths)
+		if err != nil {
+			return nil, err
+		}
+		path = append(append([]string{}, parentPath...), path...)
+	}
+	compartmentPaths[compartmentId] = path
+	return path, nil
+}
+
+func getModuleResourceKey(resource *tf_export.OCIResource) string {
+	if resource.TerraformTypeInfo != nil && resource.TerraformTypeInfo.IsDataSource {
+		return "data." + resource.GetTerraformReference()
+	}
+	return resource.GetTerraformReference()
+}
+
+// writeModulesConfiguration writes the configuration of the resources of the step to the module of their compartment
+func (r *resourceDiscoveryBaseStep) writeModulesConfiguration() error {
+	builders := map[*exportModule]*strings.Builder{}
+	var modules []*exportModule
+
+	exportedResourceCount := 0
+	for _, resource := range r.discoveredResources {
+		module := r.modules.resourceModules[getModuleResourceKey(resource)]
+		builder, exists := builders[module]
+		if !exists {
+			builder = &strings.Builder{}
+			builder.WriteString("## This configuration was generated by terraform-provider-oci\n\n")
+			builders[module] = builder
+			modules = append(modules, module)
+		}
+
+		exported, err := r.writeResourceConfiguration(builder, resource)
+		if err != nil {
+			return err
+		}
+		if !exported {
+			continue
+		}
+		exportedResourceCount++
+
+		// Import blocks are only allowed in the root module
+		if r.ctx.GenerateImportBlocks {
+			if importBlock := resource.GetImportBlockHclStringForAddress(fmt.Sprintf("module.%s.%s", module.name, resource.GetTerraformReference())); importBlock != "" {
+				r.modules.lock.Lock()
+				r.modules.importBlocks = append(r.modules.importBlocks, importBlock)
+				r.modules.lock.Unlock()
+			}
+		}
+	}
+
+	for _, module := range modules {
+		moduleDir := module.dir(*r.ctx.OutputDir)
+		if err := os.MkdirAll(moduleDir, os.ModePerm); err != nil {
+			return err
+		}
+		config := r.modules.resolveReferences(module, builders[module].String())
+		if err := writeConfigurationFile(filepath.Join(moduleDir, fmt.Sprintf("%s.tf", r.name)), config); err != nil {
+			return err
+		}
+	}
+
+	r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Found %d '%s' resources. Generated under '%s' in %d modules", exportedResourceCount, r.name, filepath.Join(*r.ctx.OutputDir, globalvar.ModulesDir), len(modules)))
+	r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Time taken for discovery: %v, generating state: %v", r.timeTakenForDiscovery, r.timeTakenForGeneratingState))
+	return nil
+}
+
+// resolveReferences replaces the references to resources of other modules with input variables of the module, and
+// records the variables that the root module passes to the module
+func (m *exportModules) resolveReferences(module *exportModule, config string) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	config = moduleReferenceRegex.ReplaceAllStringFunc(config, func(reference string) string {
+		parts := moduleReferenceRegex.FindStringSubmatch(reference)
+		resourceKey := fmt.Sprintf("%s%s.%s", parts[1], parts[2], parts[3])
+		referencedModule, exists := m.resourceModules[resourceKey]
+		if !exists || referencedModule == module {
+			return reference
+		}
+
+		variableName := strings.ReplaceAll(reference, ".", "_")
+		referencedModule.outputs[variableName] = reference
+		module.variables[variableName] = fmt.Sprintf("module.%s.%s", referencedModule.name, variableName)
+		return tf_export.TfHclVersionvar.GetVarHclString(variableName)
+	})
+
+	for _, variable := range moduleVariableRegex.FindAllStringSubmatch(config, -1) {
+		module.usedVars[variable[1]] = true
+	}
+	return config
+}
+
+// writeModuleFiles writes the variables and outputs of each module, and the root module that calls them
+func (m *exportModules) writeModuleFiles() error {
+	var modules []*exportModule
+	for _, module := range m.modules {
+		modules = append(modules, module)
+		// the root module passes its variables, e.g. compartment_ocid, to the modules that use them
+		for variable := range module.usedVars {
+			if _, isRootVariable := tf_export.Vars[variable]; isRootVariable {
+				if _, exists := module.variables[variable]; !exists {
+					module.variables[variable] = tf_export.TfHclVersionvar.GetVarHclString(variable)
+				}
+			}
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].name < modules[j].name
+	})
+
+	rootBuilder := &strings.Builder{}
+	rootBuilder.WriteString("## This configuration was generated by terraform-provider-oci\n\n")
+	for _, module := range modules {
+		variablesBuilder := &strings.Builder{}
+		for _, variable := range getSortedKeys(module.variables) {
+			variablesBuilder.WriteString(fmt.Sprintf("variable %s {}\n", variable))
+		}
+		if err := writeConfigurationFile(filepath.Join(module.dir(*m.ctx.OutputDir), globalvar.ModuleVariablesFile), variablesBuilder.String()); err != nil {
+			return err
+		}
+
+		outputsBuilder := &strings.Builder{}
+		for _, output := range getSortedKeys(module.outputs) {
+			outputsBuilder.WriteString(fmt.Sprintf("output %s {\nvalue = %s\n}\n\n", output, module.outputs[output]))
+		}
+		if err := writeConfigurationFile(filepath.Join(module.dir(*m.ctx.OutputDir), globalvar.ModuleOutputsFile), outputsBuilder.String()); err != nil {
+			return err
+		}
+
+		rootBuilder.WriteString(fmt.Sprintf("module %s {\nsource = %q\n", module.name, "./"+filepath.ToSlash(filepath.Join(append([]string{globalvar.ModulesDir}, module.path...)...))))
+		for _, variable := range getSortedKeys(module.variables) {
+			rootBuilder.WriteString(fmt.Sprintf("%s = %s\n", variable, module.variables[variable]))
+		}
+		rootBuilder.WriteString("}\n\n")
+	}
+
+	if err := writeConfigurationFile(filepath.Join(*m.ctx.OutputDir, globalvar.ModulesFile), rootBuilder.String()); err != nil {
+		return err
+	}
+
+	if len(m.importBlocks) > 0 {
+		sort.Strings(m.importBlocks)
+		importFile := filepath.Join(*m.ctx.OutputDir, globalvar.ImportBlocksFile)
+		if err := writeConfigurationFile(importFile, strings.Join(m.importBlocks, "")); err != nil {
+			return err
+		}
+		m.ctx.SummaryStatements = append(m.ctx.SummaryStatements, fmt.Sprintf("Generated %d import blocks under '%s', run terraform plan to review the resources to import", len(m.importBlocks), importFile))
+	}
+	utils.Logf("[INFO] generated %d modules under '%s'", len(modules), filepath.Join(*m.ctx.OutputDir, globalvar.ModulesDir))
+	return nil
+}
+
+func getSortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	oci_identity "github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/stretchr/testify/assert"
+
+	tf_client "github.com/oracle/terraform-provider-oci/internal/client"
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+)
+
+func mockCompartmentTree(compartments map[string][2]string) func() {
+	getCompartment := identityClientGetCompartmentVar
+	identityClientGetCompartmentVar = func(clients *tf_client.OracleClients, request oci_identity.GetCompartmentRequest) (oci_identity.GetCompartmentResponse, error) {
+		compartment, exists := compartments[*request.CompartmentId]
+		if !exists {
+			return oci_identity.GetCompartmentResponse{}, fmt.Errorf("compartment %s not found", *request.CompartmentId)
+		}
+		response := oci_identity.GetCompartmentResponse{Compartment: oci_identity.Compartment{Id: request.CompartmentId, Name: &compartment[0]}}
+		if compartment[1] != "" {
+			parent := compartment[1]
+			response.CompartmentId = &parent
+		}
+		return response, nil
+	}
+	return func() {
+		identityClientGetCompartmentVar = getCompartment
+	}
+}
+
+func TestUnitGetCompartmentPath(t *testing.T) {
+	defer mockCompartmentTree(map[string][2]string{
+		"tenancy":  {"my tenancy", ""},
+		"root":     {"root", "tenancy"},
+		"child":    {"child", "root"},
+		"grandkid": {"grand.kid", "child"},
+		"other":    {"other", "tenancy"},
+	})()
+	compartmentId := "root"
+	ctx := &tf_export.ResourceDiscoveryContext{ExportCommandArgs: &tf_export.ExportCommandArgs{CompartmentId: &compartmentId}}
+	compartmentPaths := map[string][]string{}
+
+	path, err := getCompartmentPath(ctx, "grandkid", compartmentPaths)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"root", "child", "grand_kid"}, path)
+	assert.Equal(t, []string{"root", "child"}, compartmentPaths["child"])
+
+	path, err = getCompartmentPath(ctx, "other", compartmentPaths)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"my_tenancy", "other"}, path)
+
+	_, err = getCompartmentPath(ctx, "unknown", compartmentPaths)
+	assert.Error(t, err)
+}
+
+func TestUnitWriteModulesConfiguration(t *testing.T) {
+	initResourceDiscoveryTests()
+	defer cleanupResourceDiscoveryTests()
+	defer mockCompartmentTree(map[string][2]string{
+		"root":  {"root", ""},
+		"child": {"child", "root"},
+	})()
+	getHclStringFromGenericMap := tf_export.GetHclStringFromGenericMap
+	defer func() { tf_export.GetHclStringFromGenericMap = getHclStringFromGenericMap }()
+	tf_export.GetHclStringFromGenericMap = func(builder *strings.Builder, ociRes *tf_export.OCIResource, interpolationMap map[string]string) error {
+		builder.WriteString(fmt.Sprintf("resource %s %s {\n", ociRes.TerraformClass, ociRes.TerraformName))
+		builder.WriteString("compartment_id = var.compartment_ocid\n")
+		if parentId, ok := ociRes.SourceAttributes["parent_id"]; ok {
+			builder.WriteString(fmt.Sprintf("parent_id = %s\n", parentId))
+		}
+		builder.WriteString("}\n\n")
+		return nil
+	}
+	vars := tf_export.Vars
+	defer func() { tf_export.Vars = vars }()
+	tf_export.Vars = map[string]string{"compartment_ocid": "\"root\""}
+
+	compartmentId := "root"
+	outputDir := t.TempDir()
+	ctx := &tf_export.ResourceDiscoveryContext{
+		ExportCommandArgs: &tf_export.ExportCommandArgs{
+			CompartmentId:        &compartmentId,
+			OutputDir:            &outputDir,
+			OutputLayout:         tf_export.OutputLayoutModules,
+			GenerateImportBlocks: true,
+		},
+	}
+	step := &resourceDiscoveryWithGraph{
+		resourceDiscoveryBaseStep: resourceDiscoveryBaseStep{
+			ctx:  ctx,
+			name: "testing",
+			discoveredResources: []*tf_export.OCIResource{
+				{
+					CompartmentId:     "root",
+					TerraformResource: tf_export.TerraformResource{Id: "ocid1.parent.0", TerraformClass: "oci_test_parent", TerraformName: "parent_0"},
+				},
+				{
+					CompartmentId:     "child",
+					SourceAttributes:  map[string]interface{}{"parent_id": "oci_test_parent.parent_0.id"},
+					TerraformResource: tf_export.TerraformResource{Id: "ocid1.child.0", TerraformClass: "oci_test_child", TerraformName: "child_0"},
+				},
+			},
+		},
+	}
+	steps := []resourceDiscoveryStep{step}
+
+	modules, err := newExportModules(ctx, steps)
+	if !assert.NoError(t, err) {
+		return
+	}
+	step.modules = modules
+	assert.NoError(t, step.writeConfiguration())
+	assert.NoError(t, modules.writeModuleFiles())
+
+	readFile := func(path ...string) string {
+		content, err := os.ReadFile(filepath.Join(append([]string{outputDir}, path...)...))
+		assert.NoError(t, err)
+		return string(content)
+	}
+
+	childConfig := readFile("modules", "root", "child", "testing.tf")
+	assert.Contains(t, childConfig, "parent_id      = var.oci_test_parent_parent_0_id")
+	assert.Contains(t, readFile("modules", "root", "child", "variables.tf"), "variable oci_test_parent_parent_0_id {}")
+	assert.Contains(t, readFile("modules", "root", "child", "variables.tf"), "variable compartment_ocid {}")
+	assert.Contains(t, readFile("modules", "root", "outputs.tf"), "value = oci_test_parent.parent_0.id")
+	assert.NotContains(t, readFile("modules", "root", "testing.tf"), "var.oci_test_parent")
+
+	rootConfig := readFile("main.tf")
+	assert.Contains(t, rootConfig, "module root {")
+	assert.Contains(t, rootConfig, "source           = \"./modules/root\"")
+	assert.Contains(t, rootConfig, "oci_test_parent_parent_0_id = module.root.oci_test_parent_parent_0_id")
+	assert.Contains(t, rootConfig, "compartment_ocid            = var.compartment_ocid")
+
+	importConfig := readFile("import.tf")
+	assert.Contains(t, importConfig, "to = module.root_child.oci_test_child.child_0")
+	assert.Contains(t, importConfig, "to = module.root.oci_test_parent.parent_0")
+}
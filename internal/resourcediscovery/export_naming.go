@@ -0,0 +1,46 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+	"github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+// applyNamingTemplate names the discovered resources from the naming_template argument, resources are named
+// before they are processed so that the references to them use the new names
+func applyNamingTemplate(ctx *tf_export.ResourceDiscoveryContext, resources []*tf_export.OCIResource) {
+	namingTemplate := tf_export.ResourceNamingTemplate
+	if namingTemplate == nil {
+		return
+	}
+
+	for _, resource := range resources {
+		var compartmentPath []string
+		if namingTemplate.UsesCompartmentPath && resource.CompartmentId != "" {
+			path, err := getNamingCompartmentPath(ctx, resource.CompartmentId)
+			if err != nil {
+				utils.Logf("[WARN] unable to get the compartment path of %s to name it from naming_template: %v", resource.Id, err)
+			}
+			compartmentPath = path
+		}
+
+		if terraformName, ok := namingTemplate.GetTerraformName(resource, compartmentPath); ok {
+			resource.TerraformName = terraformName
+		} else {
+			utils.Debugf("[DEBUG] naming_template %s has no value for %s, keeping the name %s", namingTemplate.Template, resource.Id, resource.TerraformName)
+		}
+	}
+}
+
+// getNamingCompartmentPath returns the path of the compartment from the compartment of the context, the paths are cached on the context
+func getNamingCompartmentPath(ctx *tf_export.ResourceDiscoveryContext, compartmentId string) ([]string, error) {
+	ctx.CtxLock.Lock()
+	defer ctx.CtxLock.Unlock()
+
+	if ctx.CompartmentPaths == nil {
+		ctx.CompartmentPaths = map[string][]string{}
+	}
+	return getCompartmentPath(ctx, compartmentId, ctx.CompartmentPaths)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+)
+
+func TestUnitApplyNamingTemplate(t *testing.T) {
+	defer mockCompartmentTree(map[string][2]string{
+		"root":  {"root", ""},
+		"child": {"Prod Network", "root"},
+	})()
+	resourceNameCount := tf_export.ResourceNameCount
+	defer func() {
+		tf_export.ResourceNamingTemplate, tf_export.ResourceNameCount = nil, resourceNameCount
+	}()
+	tf_export.ResourceNameCount = map[string]int{}
+
+	namingTemplate, err := tf_export.NewNamingTemplate("{{compartment_path}}_{{abbrev}}_{{display_name}}")
+	if !assert.NoError(t, err) {
+		return
+	}
+	tf_export.ResourceNamingTemplate = namingTemplate
+
+	compartmentId := "root"
+	ctx := &tf_export.ResourceDiscoveryContext{ExportCommandArgs: &tf_export.ExportCommandArgs{CompartmentId: &compartmentId}}
+	newResource := func(compartmentId string, name string, attributes map[string]interface{}) *tf_export.OCIResource {
+		return &tf_export.OCIResource{
+			TerraformResource: tf_export.TerraformResource{
+				TerraformClass:    "oci_core_vcn",
+				TerraformName:     name,
+				TerraformTypeInfo: &tf_export.TerraformResourceHints{ResourceAbbreviation: "vcn"},
+			},
+			CompartmentId:    compartmentId,
+			SourceAttributes: attributes,
+		}
+	}
+	resources := []*tf_export.OCIResource{
+		newResource("child", "export_web", map[string]interface{}{"display_name": "web"}),
+		newResource("child", "export_web_1", map[string]interface{}{"display_name": "web"}),
+		newResource("root", "export_vcn", map[string]interface{}{}),
+		newResource("unknown", "export_db", map[string]interface{}{"display_name": "db"}),
+	}
+
+	applyNamingTemplate(ctx, resources)
+
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.TerraformName)
+	}
+	// duplicate names get a suffix, resources without a value for a placeholder keep their name
+	assert.Equal(t, []string{"root_Prod_Network_vcn_web", "root_Prod_Network_vcn_web_1", "export_vcn", "export_db"}, names)
+	assert.Equal(t, []string{"root", "Prod_Network"}, ctx.CompartmentPaths["child"])
+}
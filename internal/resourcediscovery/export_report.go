@@ -0,0 +1,97 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+	"github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+// Status of the export in the report, stable across versions of the report schema
+var exportReportStatus = map[Status]string{
+	StatusSuccess:        "success",
+	StatusFail:           "fail",
+	StatusPartialSuccess: "partial_success",
+}
+
+func newExportStepReports(steps []resourceDiscoveryStep) []tf_export.ExportStepReport {
+	result := make([]tf_export.ExportStepReport, 0, len(steps))
+	for _, step := range steps {
+		baseStep := step.getBaseStep()
+		result = append(result, tf_export.ExportStepReport{
+			Name:                            baseStep.name,
+			DiscoveredResources:             len(baseStep.discoveredResources),
+			OmittedResources:                len(baseStep.omittedResources),
+			TimeTakenToDiscoverSeconds:      baseStep.timeTakenForDiscovery.Seconds(),
+			TimeTakenToGenerateStateSeconds: baseStep.timeTakenForGeneratingState.Seconds(),
+		})
+	}
+	return result
+}
+
+// newExportReport returns the report of the export, ctx is nil if the export failed before discovery started
+func newExportReport(args *tf_export.ExportCommandArgs, ctx *tf_export.ResourceDiscoveryContext, err error, status Status) *tf_export.ExportReport {
+	report := &tf_export.ExportReport{
+		SchemaVersion:                tf_export.ExportReportSchemaVersion,
+		Status:                       exportReportStatus[status],
+		Steps:                        []tf_export.ExportStepReport{},
+		Errors:                       []tf_export.ExportErrorReport{},
+		MissingAttributesPerResource: map[string][]string{},
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	if args.CompartmentId != nil {
+		report.CompartmentId = *args.CompartmentId
+	}
+	if args.OutputDir != nil {
+		report.OutputPath = *args.OutputDir
+	}
+	if ctx == nil {
+		return report
+	}
+
+	report.TotalResources = len(ctx.DiscoveredResources)
+	report.TimeTakenToDiscoverSeconds = ctx.TimeTakenToDiscover.Seconds()
+	report.TimeTakenToGenerateStateSeconds = ctx.TimeTakenToGenerateState.Seconds()
+	report.TimeTakenForEntireExportSeconds = ctx.TimeTakenForEntireExport.Seconds()
+	if ctx.StepReports != nil {
+		report.Steps = ctx.StepReports
+	}
+	report.Errors = ctx.GetErrorReports()
+	for resource, attributes := range ctx.MissingAttributesPerResource {
+		report.MissingAttributesPerResource[resource] = attributes
+	}
+	return report
+}
+
+// writeExportReport writes the report of the export to report_path
+func writeExportReport(args *tf_export.ExportCommandArgs, ctx *tf_export.ResourceDiscoveryContext, err error, status Status) error {
+	reportBytes, jsonErr := json.MarshalIndent(newExportReport(args, ctx, err, status), "", "  ")
+	if jsonErr != nil {
+		return jsonErr
+	}
+
+	reportPath := *args.ReportPath
+	if dir := filepath.Dir(reportPath); dir != "" {
+		if mkdirErr := os.MkdirAll(dir, os.ModePerm); mkdirErr != nil {
+			return fmt.Errorf("[ERROR] error creating directory for report at %s: %s", reportPath, mkdirErr.Error())
+		}
+	}
+	tmpReportPath := reportPath + ".tmp"
+	if writeErr := ioutil.WriteFile(tmpReportPath, reportBytes, 0644); writeErr != nil {
+		return fmt.Errorf("[ERROR] error writing report at %s: %s", reportPath, writeErr.Error())
+	}
+	if renameErr := os.Rename(tmpReportPath, reportPath); renameErr != nil {
+		return fmt.Errorf("[ERROR] error writing report at %s: %s", reportPath, renameErr.Error())
+	}
+	utils.Logf("[INFO] export report written to file at: %s", reportPath)
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+)
+
+func TestUnitWriteExportReport(t *testing.T) {
+	compartmentId := "ocid1.compartment.export"
+	outputDir := t.TempDir()
+	reportPath := filepath.Join(t.TempDir(), "reports", "report.json")
+	args := &tf_export.ExportCommandArgs{CompartmentId: &compartmentId, OutputDir: &outputDir, ReportPath: &reportPath}
+
+	readReport := func() map[string]interface{} {
+		reportBytes, err := os.ReadFile(reportPath)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		var report map[string]interface{}
+		assert.NoError(t, json.Unmarshal(reportBytes, &report))
+		return report
+	}
+
+	// export failed before discovery
+	assert.NoError(t, writeExportReport(args, nil, fmt.Errorf("invalid arguments"), StatusFail))
+	report := readReport()
+	assert.Equal(t, "1", report["schema_version"])
+	assert.Equal(t, "fail", report["status"])
+	assert.Equal(t, "invalid arguments", report["error"])
+	assert.Equal(t, []interface{}{}, report["steps"])
+	assert.Equal(t, []interface{}{}, report["errors"])
+
+	ctx := &tf_export.ResourceDiscoveryContext{
+		ExportCommandArgs:            args,
+		DiscoveredResources:          []*tf_export.OCIResource{{}, {}},
+		MissingAttributesPerResource: map[string][]string{"oci_core_instance.export_instance": {"source_details.source_id"}},
+		TimeTakenToDiscover:          1500 * time.Millisecond,
+	}
+	ctx.AddErrorToList(&tf_export.ResourceDiscoveryError{
+		ResourceType:   "oci_core_vcn",
+		ParentResource: "export",
+		Error:          fmt.Errorf("list failed"),
+		ResourceGraph:  &tf_export.TerraformResourceGraph{"oci_core_vcn": {{TerraformResourceHints: &tf_export.TerraformResourceHints{ResourceClass: "oci_core_subnet"}}}},
+	})
+	step := &resourceDiscoveryWithGraph{
+		resourceDiscoveryBaseStep: resourceDiscoveryBaseStep{
+			ctx:                   ctx,
+			name:                  "core",
+			discoveredResources:   ctx.DiscoveredResources,
+			omittedResources:      []*tf_export.OCIResource{{}},
+			timeTakenForDiscovery: time.Second,
+		},
+	}
+	ctx.StepReports = newExportStepReports([]resourceDiscoveryStep{step})
+
+	assert.NoError(t, writeExportReport(args, ctx, nil, StatusPartialSuccess))
+	report = readReport()
+	assert.Equal(t, "partial_success", report["status"])
+	assert.NotContains(t, report, "error")
+	assert.Equal(t, compartmentId, report["compartment_id"])
+	assert.Equal(t, float64(2), report["total_resources"])
+	assert.Equal(t, 1.5, report["time_taken_to_discover_seconds"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name":                                 "core",
+		"discovered_resources":                 float64(2),
+		"omitted_resources":                    float64(1),
+		"time_taken_to_discover_seconds":       float64(1),
+		"time_taken_to_generate_state_seconds": float64(0),
+	}}, report["steps"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"resource_type":           "oci_core_vcn",
+		"parent_resource":         "export",
+		"error":                   "list failed",
+		"not_discovered_children": []interface{}{"oci_core_subnet"},
+	}}, report["errors"])
+	assert.Equal(t, map[string]interface{}{"oci_core_instance.export_instance": []interface{}{"source_details.source_id"}}, report["missing_attributes_per_resource"])
+}
@@ -126,6 +126,8 @@ type resourceDiscoveryBaseStep struct {
 	discoveryParallelism        bool
 	timeTakenForDiscovery       time.Duration
 	timeTakenForGeneratingState time.Duration
+	modules                     *exportModules // set when the configuration is generated as one module per compartment
+	providerAlias               string         // alias of the provider of the region of the discovered resources
 }
 
 func (r *resourceDiscoveryBaseStep) mergeTempStateFiles(tmpStateOutputDir string) error {
@@ -303,13 +305,13 @@ func (r *resourceDiscoveryBaseStep) writeConfiguration() error {
 	if len(r.getDiscoveredResources()) == 0 {
 		return nil
 	}
-	configOutputFile := fmt.Sprintf("%s%s%s.tf", *r.ctx.OutputDir, string(os.PathSeparator), r.name)
-	tmpConfigOutputFile := fmt.Sprintf("%s%s%s.tf.tmp", *r.ctx.OutputDir, string(os.PathSeparator), r.name)
-	file, err := os.OpenFile(tmpConfigOutputFile, os.O_CREATE|os.O_RDWR, 0666)
-	if err != nil {
-		return err
+
+	if r.modules != nil {
+		return r.writeModulesConfiguration()
 	}
 
+	configOutputFile := fmt.Sprintf("%s%s%s.tf", *r.ctx.OutputDir, string(os.PathSeparator), r.name)
+
 	// Build the HCL config
 	// Note that we still build a TF file even if no resources were discovered for this TF file.
 	// A user may run this command multiple times and may see stale resources if we don't overwrite the file with
@@ -318,43 +320,87 @@ func (r *resourceDiscoveryBaseStep) writeConfiguration() error {
 	builder.WriteString("## This configuration was generated by terraform-provider-oci\n\n")
 
 	exportedResourceCount := 0
+	importBlockCount := 0
 	for _, resource := range r.discoveredResources {
+		exported, err := r.writeResourceConfiguration(builder, resource)
+		if err != nil {
+			return err
+		}
+		if !exported {
+			continue
+		}
+		exportedResourceCount++
 
-		// Skip writing the config for resources for which import command failed
-		if !resource.IsErrorResource {
-			utils.Logf("[INFO] ===> Generating resource '%s'", resource.GetTerraformReference())
-			if err := resource.GetHCLString(builder, tf_export.ReferenceMap); err != nil {
-				_ = file.Close()
-				return err
+		// Import blocks let Terraform v1.5+ import the resources on the next apply instead of running terraform import for each of them
+		if r.ctx.GenerateImportBlocks {
+			if importBlock := resource.GetImportBlockHclString(); importBlock != "" {
+				builder.WriteString(importBlock)
+				importBlockCount++
 			}
+		}
+	}
 
-			if resource.TerraformTypeInfo != nil && len(resource.TerraformTypeInfo.IgnorableRequiredMissingAttributes) > 0 {
-				attributes := make([]string, 0, len(resource.TerraformTypeInfo.IgnorableRequiredMissingAttributes))
-				for attribute := range resource.TerraformTypeInfo.IgnorableRequiredMissingAttributes {
-					attributes = append(attributes, attribute)
-				}
-				missingAttributesPerResourceLock.Lock()
-				if r.ctx.MissingAttributesPerResource == nil {
-					r.ctx.MissingAttributesPerResource = make(map[string][]string)
-				}
-				r.ctx.MissingAttributesPerResource[resource.GetTerraformReference()] = attributes
-				missingAttributesPerResourceLock.Unlock()
-			}
+	if err := writeConfigurationFile(configOutputFile, builder.String()); err != nil {
+		return err
+	}
 
-			r.ctx.DiscoveredResources = append(r.ctx.DiscoveredResources, resource)
-			exportedResourceCount++
-		} else {
-			// remove missing attributes info if present for a failed resource
-			missingAttributesPerResourceLock.Lock()
-			if _, ok := r.ctx.MissingAttributesPerResource[resource.GetTerraformReference()]; ok {
-				delete(r.ctx.MissingAttributesPerResource, resource.GetTerraformReference())
-			}
-			missingAttributesPerResourceLock.Unlock()
+	if r.ctx.TargetSpecificResources {
+		r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Found %d resources. Generated under '%s'", exportedResourceCount, configOutputFile))
+	} else {
+		r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Found %d '%s' resources. Generated under '%s'", exportedResourceCount, r.name, configOutputFile))
+	}
+	if r.ctx.GenerateImportBlocks {
+		r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Generated %d import blocks under '%s', run terraform plan to review the resources to import", importBlockCount, configOutputFile))
+	}
+	r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Time taken for discovery: %v, generating state: %v", r.timeTakenForDiscovery, r.timeTakenForGeneratingState))
+	return nil
+}
+
+// writeResourceConfiguration writes the HCL of the resource to the builder and returns false if the resource is skipped
+func (r *resourceDiscoveryBaseStep) writeResourceConfiguration(builder *strings.Builder, resource *tf_export.OCIResource) (bool, error) {
+	// Skip writing the config for resources for which import command failed
+	if resource.IsErrorResource {
+		// remove missing attributes info if present for a failed resource
+		missingAttributesPerResourceLock.Lock()
+		if _, ok := r.ctx.MissingAttributesPerResource[resource.GetTerraformReference()]; ok {
+			delete(r.ctx.MissingAttributesPerResource, resource.GetTerraformReference())
+		}
+		missingAttributesPerResourceLock.Unlock()
+		return false, nil
+	}
+
+	utils.Logf("[INFO] ===> Generating resource '%s'", resource.GetTerraformReference())
+	if err := resource.GetHCLString(builder, tf_export.ReferenceMap); err != nil {
+		return false, err
+	}
+
+	if resource.TerraformTypeInfo != nil && len(resource.TerraformTypeInfo.IgnorableRequiredMissingAttributes) > 0 {
+		attributes := make([]string, 0, len(resource.TerraformTypeInfo.IgnorableRequiredMissingAttributes))
+		for attribute := range resource.TerraformTypeInfo.IgnorableRequiredMissingAttributes {
+			attributes = append(attributes, attribute)
 		}
+		missingAttributesPerResourceLock.Lock()
+		if r.ctx.MissingAttributesPerResource == nil {
+			r.ctx.MissingAttributesPerResource = make(map[string][]string)
+		}
+		r.ctx.MissingAttributesPerResource[resource.GetTerraformReference()] = attributes
+		missingAttributesPerResourceLock.Unlock()
+	}
+
+	r.ctx.DiscoveredResources = append(r.ctx.DiscoveredResources, resource)
+	return true, nil
+}
+
+// writeConfigurationFile formats the HCL config and writes it to the given file
+func writeConfigurationFile(configOutputFile string, config string) error {
+	tmpConfigOutputFile := fmt.Sprintf("%s.tmp", configOutputFile)
+	file, err := os.OpenFile(tmpConfigOutputFile, os.O_CREATE|os.O_RDWR, 0666)
+	if err != nil {
+		return err
 	}
 
 	// Format the HCL config
-	formattedString := hclwrite.Format([]byte(builder.String()))
+	formattedString := hclwrite.Format([]byte(config))
 
 	_, err = file.WriteString(string(formattedString))
 	if err != nil {
@@ -366,17 +412,7 @@ func (r *resourceDiscoveryBaseStep) writeConfiguration() error {
 		return fErr
 	}
 
-	if err := os.Rename(tmpConfigOutputFile, configOutputFile); err != nil {
-		return err
-	}
-
-	if r.ctx.TargetSpecificResources {
-		r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Found %d resources. Generated under '%s'", exportedResourceCount, configOutputFile))
-	} else {
-		r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Found %d '%s' resources. Generated under '%s'", exportedResourceCount, r.name, configOutputFile))
-	}
-	r.ctx.SummaryStatements = append(r.ctx.SummaryStatements, fmt.Sprintf("Time taken for discovery: %v, generating state: %v", r.timeTakenForDiscovery, r.timeTakenForGeneratingState))
-	return nil
+	return os.Rename(tmpConfigOutputFile, configOutputFile)
 }
 
 func (r *resourceDiscoveryBaseStep) getOmittedResources() []*tf_export.OCIResource {
@@ -421,6 +457,7 @@ func (r *resourceDiscoveryWithGraph) discover() error {
 	r.discoveredResources = []*tf_export.OCIResource{}
 	r.omittedResources = []*tf_export.OCIResource{}
 	for _, resource := range ociResources {
+		resource.ProviderAlias = r.providerAlias
 		if !resource.OmitFromExport {
 
 			tf_export.RefMapLock.Lock()
@@ -522,6 +559,7 @@ func (r *resourceDiscoveryWithTargetIds) discover() error {
 			ociResource.TerraformName = fmt.Sprintf("export_%s", resourceHint.ResourceAbbreviation)
 			ociResource.TerraformName = tf_export.CheckDuplicateResourceName(ociResource.TerraformName)
 		}
+		applyNamingTemplate(r.ctx, []*tf_export.OCIResource{ociResource})
 
 		r.discoveredResources = append(r.discoveredResources, ociResource)
 
@@ -213,6 +213,63 @@ func TestUnitwriteConfiguration(t *testing.T) {
 	os.RemoveAll(outputDir)
 }
 
+func TestUnitwriteConfigurationWithImportBlocks(t *testing.T) {
+	initResourceDiscoveryTests()
+	defer cleanupResourceDiscoveryTests()
+	compartmentId := resourceDiscoveryTestCompartmentOcid
+	outputDir := t.TempDir()
+	ctx := &tf_export.ResourceDiscoveryContext{
+		TenancyOcid: resourceDiscoveryTestTenancyOcid,
+		ExportCommandArgs: &tf_export.ExportCommandArgs{
+			CompartmentId:        &compartmentId,
+			OutputDir:            &outputDir,
+			GenerateImportBlocks: true,
+		},
+	}
+	r := resourceDiscoveryBaseStep{
+		ctx:  ctx,
+		name: "import_blocks",
+		discoveredResources: []*tf_export.OCIResource{
+			{
+				CompartmentId: resourceDiscoveryTestCompartmentOcid,
+				TerraformResource: tf_export.TerraformResource{
+					Id:             "ocid1.parent.abcdefghiklmnop.0",
+					ImportId:       "parents/ocid1.parent.abcdefghiklmnop.0",
+					TerraformClass: "oci_test_parent",
+					TerraformName:  "parent_1",
+				},
+			},
+			{
+				CompartmentId: resourceDiscoveryTestCompartmentOcid,
+				TerraformResource: tf_export.TerraformResource{
+					Id:             "ocid1.parent.abcdefghiklmnop.1",
+					TerraformClass: "oci_test_parent",
+					TerraformName:  "parent_2",
+				},
+				IsErrorResource: true,
+			},
+		},
+	}
+	tf_export.GetHclStringFromGenericMap = func(builder *strings.Builder, ociRes *tf_export.OCIResource, interpolationMap map[string]string) error {
+		builder.WriteString(fmt.Sprintf("resource %s %s {\n}\n\n", ociRes.TerraformClass, ociRes.TerraformName))
+		return nil
+	}
+
+	if err := r.writeConfiguration(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, err := os.ReadFile(fmt.Sprintf("%s%simport_blocks.tf", outputDir, string(os.PathSeparator)))
+	if err != nil {
+		t.Fatalf("unable to read the generated configuration: %v", err)
+	}
+	if !strings.Contains(string(config), "import {\n  to = oci_test_parent.parent_1\n  id = \"parents/ocid1.parent.abcdefghiklmnop.0\"\n}") {
+		t.Errorf("import block not found in the generated configuration:\n%s", config)
+	}
+	if strings.Contains(string(config), "parent_2") {
+		t.Errorf("unexpected import block for a failed resource in the generated configuration:\n%s", config)
+	}
+}
+
 func TestUnitwriteTmpState(t *testing.T) {
 	initResourceDiscoveryTests()
 	defer cleanupResourceDiscoveryTests()
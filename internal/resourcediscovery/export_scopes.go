@@ -0,0 +1,176 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	oci_common "github.com/oracle/oci-go-sdk/v65/common"
+	oci_identity "github.com/oracle/oci-go-sdk/v65/identity"
+
+	tf_client "github.com/oracle/terraform-provider-oci/internal/client"
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+	"github.com/oracle/terraform-provider-oci/internal/tfresource"
+)
+
+// exportScope is a compartment of a region whose resources are discovered by the steps of the export.
+// All the scopes share the ReferenceMap, so that the resources of a scope reference the resources of the other scopes.
+type exportScope struct {
+	ctx            *tf_export.ResourceDiscoveryContext // context to discover the resources, with the clients of the region and the filters of the compartment
+	compartmentId  string
+	compartmentVar string // variable of the compartment OCID in the generated configuration
+	isHomeScope    bool   // true for the first region of the first compartment, tenancy resources are only discovered once
+	providerAlias  string // alias of the provider of the region, empty for the region of the default provider
+	stepSuffix     string // added to the names of the steps when exporting more than one compartment or region
+}
+
+// getExportScopes returns a scope for each compartment and region of the export
+func getExportScopes(ctx *tf_export.ResourceDiscoveryContext) ([]*exportScope, error) {
+	compartments := ctx.Compartments
+	if len(compartments) == 0 {
+		compartments = []tf_export.ExportCompartment{{CompartmentId: ctx.CompartmentId}}
+	}
+	regions := ctx.Regions
+	if len(regions) == 0 {
+		// region of the provider
+		regions = []string{""}
+	}
+
+	var result []*exportScope
+	compartmentLabels := map[string]bool{}
+	for i, compartment := range compartments {
+		compartmentId := *compartment.CompartmentId
+		compartmentVar, compartmentSuffix := "compartment_ocid", ""
+		if len(compartments) > 1 {
+			label, err := getCompartmentLabel(ctx, compartmentId, compartmentLabels)
+			if err != nil {
+				return nil, err
+			}
+			compartmentVar = fmt.Sprintf("compartment_ocid_%s", label)
+			compartmentSuffix = fmt.Sprintf("_%s", label)
+		}
+
+		for j, region := range regions {
+			scope := &exportScope{
+				ctx:            ctx,
+				compartmentId:  compartmentId,
+				compartmentVar: compartmentVar,
+				isHomeScope:    i == 0 && j == 0,
+				stepSuffix:     compartmentSuffix,
+			}
+			if len(regions) > 1 {
+				scope.stepSuffix = fmt.Sprintf("%s_%s", scope.stepSuffix, tf_export.GetProviderAlias(region))
+			}
+			// the first region is the region of the default provider
+			if j > 0 {
+				scope.providerAlias = tf_export.GetProviderAlias(region)
+			}
+
+			clients := ctx.Clients
+			if region != "" {
+				regionClients, exists := ctx.RegionClients[region]
+				if !exists {
+					return nil, fmt.Errorf("[ERROR] no clients were created for region %s", region)
+				}
+				clients = regionClients
+			}
+			if clients != ctx.Clients || compartmentId != *ctx.CompartmentId || len(compartment.Filters) > 0 {
+				scope.ctx = newScopeContext(ctx, compartmentId, clients, compartment.Filters)
+			}
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+// newScopeContext returns a context to discover the resources of a compartment with the clients of a region
+func newScopeContext(ctx *tf_export.ResourceDiscoveryContext, compartmentId string, clients *tf_client.OracleClients, filters []tf_export.ResourceFilter) *tf_export.ResourceDiscoveryContext {
+	args := *ctx.ExportCommandArgs
+	args.CompartmentId = &compartmentId
+	args.Filters = append(append([]tf_export.ResourceFilter{}, ctx.Filters...), filters...)
+
+	return &tf_export.ResourceDiscoveryContext{
+		TerraformProviderBinaryPath: ctx.TerraformProviderBinaryPath,
+		TerraformCLIPath:            ctx.TerraformCLIPath,
+		Terraform:                   ctx.Terraform,
+		Clients:                     clients,
+		ExpectedResourceIds:         ctx.ExpectedResourceIds,
+		TenancyOcid:                 ctx.TenancyOcid,
+		DiscoveredResources:         []*tf_export.OCIResource{},
+		SummaryStatements:           []string{},
+		TargetSpecificResources:     ctx.TargetSpecificResources,
+		ResourceHintsLookup:         ctx.ResourceHintsLookup,
+		ExportCommandArgs:           &args,
+		ErrorList: tf_export.ErrorList{
+			Errors: []*tf_export.ResourceDiscoveryError{},
+		},
+	}
+}
+
+// useExportContext moves the errors found with the contexts of the scopes to the context of the export, which is used by the steps after discovery
+func useExportContext(ctx *tf_export.ResourceDiscoveryContext, steps []resourceDiscoveryStep) {
+	merged := map[*tf_export.ResourceDiscoveryContext]bool{ctx: true}
+	for _, step := range steps {
+		baseStep := step.getBaseStep()
+		if !merged[baseStep.ctx] {
+			for _, rdError := range baseStep.ctx.ErrorList.Errors {
+				ctx.AddErrorToList(rdError)
+			}
+			merged[baseStep.ctx] = true
+		}
+		baseStep.ctx = ctx
+	}
+}
+
+// getCompartmentLabel returns a unique name for the compartment used in the names of its variable and steps
+func getCompartmentLabel(ctx *tf_export.ResourceDiscoveryContext, compartmentId string, labels map[string]bool) (string, error) {
+	response, err := identityClientGetCompartmentVar(ctx.Clients, oci_identity.GetCompartmentRequest{
+		CompartmentId: &compartmentId,
+		RequestMetadata: oci_common.RequestMetadata{
+			RetryPolicy: tfresource.GetRetryPolicy(true, "identity"),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] could not get the compartment %s to export: %v", compartmentId, err)
+	}
+
+	baseLabel := compartmentId
+	if response.Name != nil {
+		baseLabel = *response.Name
+	}
+	baseLabel = strings.ToLower(moduleNameRegex.ReplaceAllString(strings.ReplaceAll(baseLabel, "-", "_"), "_"))
+	label := baseLabel
+	for i := 1; labels[label]; i++ {
+		label = fmt.Sprintf("%s_%d", baseLabel, i)
+	}
+	labels[label] = true
+	return label, nil
+}
+
+// getRegionClients creates the clients of each of the regions to export
+func getRegionClients(d *schema.ResourceData, regions []string) (map[string]*tf_client.OracleClients, error) {
+	// the clients of the region of the provider stay configured for the calls that are not specific to a region
+	configProvider := exportConfigProvider
+	configureClient := tf_client.ConfigureClientVar
+	defer func() {
+		exportConfigProvider = configProvider
+		tf_client.ConfigureClientVar = configureClient
+	}()
+
+	result := map[string]*tf_client.OracleClients{}
+	for _, region := range regions {
+		if err := d.Set(globalvar.RegionAttrName, region); err != nil {
+			return nil, err
+		}
+		clients, err := getExportConfigVar(d)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] could not create the clients of region %s: %v", region, err)
+		}
+		result[region] = clients.(*tf_client.OracleClients)
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tf_client "github.com/oracle/terraform-provider-oci/internal/client"
+	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
+)
+
+func TestUnitGetDiscoverResourceWithGraphStepsForCompartmentsAndRegions(t *testing.T) {
+	initResourceDiscoveryTests()
+	defer cleanupResourceDiscoveryTests()
+	defer mockCompartmentTree(map[string][2]string{
+		"tenancyOcid":       {"tenancy", ""},
+		"ocid1.compartment": {"Prod-Network", "tenancyOcid"},
+	})()
+	vars, referenceMap := tf_export.Vars, tf_export.ReferenceMap
+	defer func() { tf_export.Vars, tf_export.ReferenceMap = vars, referenceMap }()
+	tf_export.Vars, tf_export.ReferenceMap = map[string]string{"compartment_ocid": "\"tenancyOcid\""}, map[string]string{}
+
+	ctx := getTestCtx()
+	tenancyOcid, compartmentId := "tenancyOcid", "ocid1.compartment"
+	ctx.CompartmentId = &tenancyOcid
+	ctx.Compartments = []tf_export.ExportCompartment{
+		{CompartmentId: &tenancyOcid},
+		{CompartmentId: &compartmentId, Filters: []tf_export.ResourceFilter{&tf_export.ResourceTypeFilter{ResourceType: map[string]bool{"oci_test_child": true}, ResourceTypeOperator: tf_export.EXCLUDE}}},
+	}
+	ctx.Regions = []string{"us-ashburn-1", "us-phoenix-1"}
+	ctx.RegionClients = map[string]*tf_client.OracleClients{"us-ashburn-1": getTestClients(), "us-phoenix-1": getTestClients()}
+
+	steps, err := getDiscoverResourceWithGraphSteps(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.getBaseStep().name)
+	}
+	// tenancy resources are only discovered once, in the region of the default provider
+	assert.Equal(t, []string{
+		"tenancy_testing",
+		"compartment_testing_tenancy_us_ashburn_1",
+		"compartment_testing_tenancy_us_phoenix_1",
+		"compartment_testing_prod_network_us_ashburn_1",
+		"compartment_testing_prod_network_us_phoenix_1",
+	}, names)
+
+	phoenixStep := steps[4].getBaseStep()
+	assert.Equal(t, "us_phoenix_1", phoenixStep.providerAlias)
+	assert.Equal(t, "", steps[3].getBaseStep().providerAlias)
+	assert.True(t, phoenixStep.ctx.Clients == ctx.RegionClients["us-phoenix-1"])
+	assert.Equal(t, compartmentId, *phoenixStep.ctx.CompartmentId)
+	assert.Len(t, phoenixStep.ctx.Filters, 1)
+	assert.Equal(t, tenancyOcid, *ctx.CompartmentId)
+
+	assert.NotContains(t, tf_export.Vars, "compartment_ocid")
+	assert.Equal(t, "\"ocid1.compartment\"", tf_export.Vars["compartment_ocid_prod_network"])
+	assert.Equal(t, "var.compartment_ocid_prod_network", tf_export.ReferenceMap[compartmentId])
+
+	phoenixStep.ctx.AddErrorToList(&tf_export.ResourceDiscoveryError{ResourceType: "oci_test_parent", Error: fmt.Errorf("not found")})
+	useExportContext(ctx, steps)
+	assert.Len(t, ctx.ErrorList.Errors, 1)
+	for _, step := range steps {
+		assert.True(t, step.getBaseStep().ctx == ctx)
+	}
+}
+
+func TestUnitGenerateProviderFileWithRegions(t *testing.T) {
+	tf_export.TfHclVersionvar = &tf_export.TfHclVersion12{}
+	outputDir := t.TempDir()
+
+	assert.NoError(t, generateProviderFile(&outputDir, []string{"us-ashburn-1", "us-phoenix-1"}))
+	content, err := os.ReadFile(filepath.Join(outputDir, "provider.tf"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "provider oci {\n\tregion = var.region\n}\n\nprovider oci {\n\talias  = \"us_phoenix_1\"\n\tregion = \"us-phoenix-1\"\n}\n", string(content))
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	oci_identity "github.com/oracle/oci-go-sdk/v65/identity"
+
+	"github.com/oracle/terraform-provider-oci/internal/client"
+	"github.com/oracle/terraform-provider-oci/internal/tfresource"
+)
+
+// maxCompartmentDepth bounds the walk up the compartment tree, the tenancies have at most 6 levels of compartments
+const maxCompartmentDepth = 10
+
+func IdentityEffectivePermissionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: readIdentityEffectivePermissions,
+		Schema: map[string]*schema.Schema{
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"verb": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"inspect", "read", "use", "manage"}, true),
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"groups": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"dynamic_groups": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			// Computed
+			"is_allowed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"matching_statements": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"conditions": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_compartment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"statement": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readIdentityEffectivePermissions(d *schema.ResourceData, m interface{}) error {
+	sync := &IdentityEffectivePermissionsDataSourceCrud{}
+	sync.D = d
+	sync.Client = m.(*client.OracleClients).IdentityClient()
+
+	return tfresource.ReadResource(sync)
+}
+
+type IdentityEffectivePermissionsDataSourceCrud struct {
+	D            *schema.ResourceData
+	Client       *oci_identity.IdentityClient
+	Compartments []PolicyCompartment
+	Policies     []EvaluatedPolicy
+}
+
+func (s *IdentityEffectivePermissionsDataSourceCrud) VoidState() {
+	s.D.SetId("")
+}
+
+// Get reads the compartments from the root compartment to the compartment_id, and the policies attached to them
+func (s *IdentityEffectivePermissionsDataSourceCrud) Get() error {
+	compartmentId := s.D.Get("compartment_id").(string)
+	var compartments []PolicyCompartment
+	for id := &compartmentId; id != nil; {
+		if len(compartments) == maxCompartmentDepth {
+			return fmt.Errorf("the compartment %s is more than %d levels below the root compartment", compartmentId, maxCompartmentDepth)
+		}
+		request := oci_identity.GetCompartmentRequest{CompartmentId: id}
+		request.RequestMetadata.RetryPolicy = tfresource.GetRetryPolicy(false, "identity")
+
+		response, err := s.Client.GetCompartment(context.Background(), request)
+		if err != nil {
+			return err
+		}
+		compartment := PolicyCompartment{Id: *id}
+		if response.Name != nil {
+			compartment.Name = *response.Name
+		}
+		compartments = append([]PolicyCompartment{compartment}, compartments...)
+		// The parent of a compartment is in its compartment_id, the root compartment has none
+		id = response.CompartmentId
+	}
+	s.Compartments = compartments
+
+	s.Policies = nil
+	for _, compartment := range compartments {
+		request := oci_identity.ListPoliciesRequest{}
+		tmp := compartment.Id
+		request.CompartmentId = &tmp
+		request.LifecycleState = oci_identity.PolicyLifecycleStateActive
+		request.RequestMetadata.RetryPolicy = tfresource.GetRetryPolicy(false, "identity")
+
+		for {
+			response, err := s.Client.ListPolicies(context.Background(), request)
+			if err != nil {
+				return err
+			}
+			for _, policy := range response.Items {
+				evaluated := EvaluatedPolicy{CompartmentId: compartment.Id, Statements: policy.Statements}
+				if policy.Id != nil {
+					evaluated.Id = *policy.Id
+				}
+				if policy.Name != nil {
+					evaluated.Name = *policy.Name
+				}
+				s.Policies = append(s.Policies, evaluated)
+			}
+			if request.Page = response.OpcNextPage; request.Page == nil {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *IdentityEffectivePermissionsDataSourceCrud) SetData() error {
+	if s.Compartments == nil {
+		return nil
+	}
+
+	s.D.SetId(tfresource.GenerateDataSourceHashID("IdentityEffectivePermissionsDataSource-", IdentityEffectivePermissionsDataSource(), s.D))
+
+	request := EffectivePermissionsRequest{
+		Groups:        toStringArray(s.D.Get("groups")),
+		DynamicGroups: toStringArray(s.D.Get("dynamic_groups")),
+		Verb:          s.D.Get("verb").(string),
+		ResourceType:  s.D.Get("resource_type").(string),
+	}
+	allowed, matches := EvaluateEffectivePermissions(request, s.Compartments, s.Policies)
+
+	s.D.Set("is_allowed", allowed)
+
+	statements := []interface{}{}
+	for _, match := range matches {
+		statements = append(statements, map[string]interface{}{
+			"action":                match.Action,
+			"conditions":            match.Conditions,
+			"policy_compartment_id": match.PolicyCompartmentId,
+			"policy_id":             match.PolicyId,
+			"policy_name":           match.PolicyName,
+			"statement":             match.Statement,
+		})
+	}
+	if err := s.D.Set("matching_statements", statements); err != nil {
+		return err
+	}
+
+	return nil
+}
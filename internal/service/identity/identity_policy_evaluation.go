@@ -0,0 +1,215 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package identity
+
+import (
+	"log"
+	"strings"
+)
+
+const allResourcesType = "all-resources"
+
+var policyVerbRanks = map[string]int{"inspect": 1, "read": 2, "use": 3, "manage": 4}
+
+// policyResourceFamilies are the resource types included in the aggregate resource types
+var policyResourceFamilies = map[string][]string{
+	"autonomous-database-family": {"autonomous-databases", "autonomous-backups"},
+	"cluster-family":             {"clusters", "cluster-node-pools", "cluster-work-requests"},
+	"database-family":            {"db-systems", "db-nodes", "db-homes", "databases", "backups"},
+	"file-family":                {"file-systems", "mount-targets", "export-sets"},
+	"instance-family":            {"instances", "instance-images", "volume-attachments", "console-histories", "instance-console-connection", "app-catalog-listing", "vnic-attachments"},
+	"object-family":              {"buckets", "objects"},
+	"virtual-network-family": {"vcns", "subnets", "route-tables", "network-security-groups", "security-lists", "dhcp-options", "private-ips",
+		"public-ips", "ipv6s", "internet-gateways", "nat-gateways", "service-gateways", "local-peering-gateways", "remote-peering-connections",
+		"drgs", "drg-attachments", "cpes", "ipsec-connections", "cross-connects", "cross-connect-groups", "virtual-circuits", "vnics",
+		"vnic-attachments", "vlans"},
+	"volume-family": {"volumes", "volume-attachments", "volume-backups", "boot-volume-backups", "backup-policies", "volume-groups", "volume-group-backups"},
+}
+
+// EffectivePermissionsRequest is the access to evaluate: whether a principal with the groups and the dynamic groups,
+// given by their names or their OCIDs, has the verb on the resource type
+type EffectivePermissionsRequest struct {
+	Groups        []string
+	DynamicGroups []string
+	Verb          string
+	ResourceType  string
+}
+
+// PolicyCompartment is a compartment of the path from the root compartment to the compartment of the evaluation
+type PolicyCompartment struct {
+	Id   string
+	Name string
+}
+
+// EvaluatedPolicy is a policy attached to a compartment of the path
+type EvaluatedPolicy struct {
+	Id            string
+	Name          string
+	CompartmentId string
+	Statements    []string
+}
+
+// MatchingPolicyStatement is a statement that applies to the request
+type MatchingPolicyStatement struct {
+	PolicyId            string
+	PolicyName          string
+	PolicyCompartmentId string
+	Statement           string
+	// Action is Allow or Deny
+	Action     string
+	Conditions string
+}
+
+/*
+EvaluateEffectivePermissions returns whether the policies allow the request in the last compartment of the path, and
+the statements that apply to it. compartments is the path from the root compartment of the tenancy to the compartment
+of the evaluation, and the policies are the policies attached to the compartments of the path.
+
+The conditions of the statements depend on the request made to the service, so they cannot be evaluated locally: the
+statements with conditions are returned, but only the statements without conditions decide whether the access is
+allowed. A statement denying the access takes precedence over the statements allowing it. The statements granting
+a list of permissions and the cross-tenancy statements are not evaluated.
+*/
+func EvaluateEffectivePermissions(request EffectivePermissionsRequest, compartments []PolicyCompartment, policies []EvaluatedPolicy) (bool, []MatchingPolicyStatement) {
+	compartmentIndexes := make(map[string]int, len(compartments))
+	for index, compartment := range compartments {
+		compartmentIndexes[compartment.Id] = index
+	}
+
+	allowed, denied := false, false
+	matches := []MatchingPolicyStatement{}
+	for _, policy := range policies {
+		policyIndex, ok := compartmentIndexes[policy.CompartmentId]
+		if !ok {
+			continue
+		}
+		for _, statement := range policy.Statements {
+			parsed, err := ParsePolicyStatement(statement)
+			if err != nil {
+				log.Printf("[DEBUG] skipping the statement of the policy %s that cannot be parsed: %v", policy.Id, err)
+				continue
+			}
+			if (parsed.Action != "Allow" && parsed.Action != "Deny") ||
+				!policySubjectsMatch(parsed.Subjects, request) ||
+				!policyVerbMatches(parsed.Verb, request.Verb) ||
+				!policyResourceTypeMatches(parsed.ResourceType, request.ResourceType) ||
+				!policyLocationMatches(parsed.Location, compartments, policyIndex) {
+				continue
+			}
+
+			matches = append(matches, MatchingPolicyStatement{
+				PolicyId:            policy.Id,
+				PolicyName:          policy.Name,
+				PolicyCompartmentId: policy.CompartmentId,
+				Statement:           statement,
+				Action:              parsed.Action,
+				Conditions:          parsed.Conditions,
+			})
+			if parsed.Conditions == "" {
+				allowed = allowed || parsed.Action == "Allow"
+				denied = denied || parsed.Action == "Deny"
+			}
+		}
+	}
+	return allowed && !denied, matches
+}
+
+func policySubjectsMatch(subjects []PolicySubject, request EffectivePermissionsRequest) bool {
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case "any-user":
+			return true
+		case "any-group":
+			if len(request.Groups) > 0 || len(request.DynamicGroups) > 0 {
+				return true
+			}
+		case "group":
+			if policyPrincipalMatches(subject, request.Groups) {
+				return true
+			}
+		case "dynamic-group":
+			if policyPrincipalMatches(subject, request.DynamicGroups) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// policyPrincipalMatches compares the OCIDs exactly and the names case insensitively, the groups of the default
+// identity domain can be given with or without the domain
+func policyPrincipalMatches(subject PolicySubject, principals []string) bool {
+	for _, principal := range principals {
+		if subject.Id != "" {
+			if strings.EqualFold(subject.Id, principal) {
+				return true
+			}
+		} else if strings.EqualFold(policyPrincipalName(subject.Name), policyPrincipalName(principal)) {
+			return true
+		}
+	}
+	return false
+}
+
+func policyPrincipalName(name string) string {
+	name = strings.NewReplacer("'", "", `"`, "").Replace(strings.TrimSpace(name))
+	if domain, group, ok := strings.Cut(name, "/"); ok && strings.EqualFold(domain, "Default") {
+		return group
+	}
+	return name
+}
+
+func policyVerbMatches(statementVerb string, requestedVerb string) bool {
+	rank, ok := policyVerbRanks[statementVerb]
+	return ok && rank >= policyVerbRanks[strings.ToLower(requestedVerb)]
+}
+
+func policyResourceTypeMatches(statementResourceType string, requestedResourceType string) bool {
+	requestedResourceType = strings.ToLower(requestedResourceType)
+	if statementResourceType == allResourcesType || statementResourceType == requestedResourceType {
+		return true
+	}
+	for _, member := range policyResourceFamilies[statementResourceType] {
+		if member == requestedResourceType {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+policyLocationMatches returns whether the location of a statement attached to compartments[policyIndex] includes the
+last compartment of the path. A statement applies to its compartment and to all the compartments below it. The path
+of a compartment, e.g. Network:Prod, is relative to the compartment of the policy and can start with its name.
+*/
+func policyLocationMatches(location PolicyLocation, compartments []PolicyCompartment, policyIndex int) bool {
+	switch {
+	case location.Kind == "tenancy" && location.Name == "":
+		return policyIndex == 0
+	case location.Kind != "compartment":
+		return false
+	case location.Id != "":
+		for _, compartment := range compartments[policyIndex:] {
+			if compartment.Id == location.Id {
+				return true
+			}
+		}
+		return false
+	}
+
+	names := strings.Split(location.Name, ":")
+	for start := policyIndex; start <= policyIndex+1; start++ {
+		if start+len(names) > len(compartments) {
+			continue
+		}
+		matches := true
+		for offset, name := range names {
+			matches = matches && strings.EqualFold(strings.Trim(name, `'"`), compartments[start+offset].Name)
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -52,7 +53,8 @@ func IdentityPolicyResource() *schema.Resource {
 				MinItems:         1,
 				DiffSuppressFunc: ignorePolicyFormatDiff,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validatePolicyStatement,
 				},
 			},
 
@@ -391,21 +393,24 @@ func (s *IdentityPolicyResourceCrud) SetData() error {
 	return nil
 }
 
+// ignorePolicyFormatDiff compares the statements by their normalized form, so that changes to the whitespace or to the
+// case of the keywords are not planned as updates
 func ignorePolicyFormatDiff(k string, old string, new string, d *schema.ResourceData) bool {
-	oldHash := getOrDefault(d, "policyHash", "")
-	newHash := getMD5Hash(toStringArray(d.Get("statements")))
-	oldETag := getOrDefault(d, "lastUpdateETag", "")
-	currentETag := getOrDefault(d, "ETag", "")
-	suppressDiff := strings.EqualFold(oldHash, newHash) && strings.EqualFold(oldETag, currentETag)
-	return suppressDiff
+	if strings.HasSuffix(k, ".#") {
+		return old == new
+	}
+	return PolicyStatementsEqual(old, new)
 }
 
-func getOrDefault(d *schema.ResourceData, key string, defaultValue string) string {
-	valueString := defaultValue
-	if value, ok := d.GetOkExists(key); ok {
-		valueString = value.(string)
+func validatePolicyStatement(i interface{}, k string) ([]string, []error) {
+	statement, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := NormalizePolicyStatement(statement); err != nil {
+		return nil, []error{fmt.Errorf("%s: %v", k, err)}
 	}
-	return valueString
+	return nil, nil
 }
 
 func getMD5Hash(values []string) string {
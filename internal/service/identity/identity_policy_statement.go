@@ -0,0 +1,568 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package identity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	policyVerbs            = map[string]bool{"inspect": true, "read": true, "use": true, "manage": true}
+	policySubjectKinds     = map[string]bool{"group": true, "dynamic-group": true, "service": true, "resource": true, "user": true}
+	policyDefineKinds      = map[string]bool{"tenancy": true, "group": true, "dynamic-group": true}
+	policyConditionOps     = map[string]bool{"=": true, "!=": true, "before": true, "after": true, "between": true, "in": true}
+	policyResourceTypeRe   = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	policyPermissionRe     = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	policyOcidRe           = regexp.MustCompile(`^ocid1\.[a-z0-9-]+\.[a-z0-9-]+\.[a-z0-9-]*(\.[a-z0-9-]*)?\.[a-z0-9]+$`)
+	policyStatementActions = []string{"Allow", "Deny", "Endorse", "Admit", "Define"}
+)
+
+// PolicyStatement is a parsed policy statement
+type PolicyStatement struct {
+	// Action is Allow, Deny, Endorse, Admit or Define
+	Action   string
+	Subjects []PolicySubject
+	// Verb is empty when the statement grants a list of Permissions
+	Verb        string
+	Permissions []string
+	// ResourceType is empty when a list of permissions is granted without resource type
+	ResourceType string
+	Location     PolicyLocation
+	// Conditions is the normalized where clause without the where keyword
+	Conditions string
+	Normalized string
+}
+
+// PolicySubject is a subject of a statement. Kind is group, dynamic-group, service, resource, user, any-user or
+// any-group, and either the Id or the Name is set for the kinds other than any-user and any-group.
+type PolicySubject struct {
+	Kind string
+	Id   string
+	Name string
+}
+
+// PolicyLocation is the location of the resources of a statement. Kind is tenancy, any-tenancy or compartment. The
+// Name is the alias of the tenancy of cross-tenancy statements, or the name or the path of the compartment.
+type PolicyLocation struct {
+	Kind string
+	Id   string
+	Name string
+}
+
+type policyTokenKind int
+
+const (
+	policyWord policyTokenKind = iota
+	policyQuoted
+	policyPunct
+)
+
+type policyToken struct {
+	kind policyTokenKind
+	text string
+	// pos is the 1-based position of the token in the statement
+	pos int
+	// glued is set when the token directly follows the previous one, e.g. 'Default'/'Admins'
+	glued bool
+}
+
+/*
+policyParser is a recursive descent parser of the OCI policy language:
+
+	Allow|Deny <subjects> to <verb> <resource type>|{PERMISSIONS} [resource type] in <location> [where <conditions>]
+	Endorse <subjects> to <verb> <resource type> in tenancy <alias>|any-tenancy [where <conditions>]
+	Admit <subjects> of tenancy <alias>|any-tenancy to <verb> <resource type> in <location> [where <conditions>]
+	Define tenancy|group|dynamic-group <alias> as <ocid>
+
+The keywords are case insensitive and the statement is rendered in a normalized form as it is parsed, so that two
+statements that only differ by their whitespace or the case of their keywords have the same normalized form.
+*/
+type policyParser struct {
+	tokens []policyToken
+	next   int
+	out    []string
+	stmt   PolicyStatement
+	// locationSet is set once the resource type and the location of the statement are known, the second location
+	// of an associate statement is not kept
+	locationSet bool
+}
+
+// ParsePolicyStatement parses a policy statement, the error gives the position of the first invalid token
+func ParsePolicyStatement(statement string) (*PolicyStatement, error) {
+	tokens, err := tokenizePolicyStatement(statement)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy statement %q: %v", statement, err)
+	}
+	p := &policyParser{tokens: tokens}
+	if err := p.parseStatement(); err != nil {
+		return nil, fmt.Errorf("invalid policy statement %q: %v", statement, err)
+	}
+	p.stmt.Normalized = renderPolicyTokens(p.out)
+	return &p.stmt, nil
+}
+
+// NormalizePolicyStatement validates a policy statement and returns its normalized form
+func NormalizePolicyStatement(statement string) (string, error) {
+	parsed, err := ParsePolicyStatement(statement)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Normalized, nil
+}
+
+// PolicyStatementsEqual returns whether two policy statements have the same normalized form. Statements that cannot
+// be parsed are only equal when they are identical.
+func PolicyStatementsEqual(old string, new string) bool {
+	if old == new {
+		return true
+	}
+	normalizedOld, err := NormalizePolicyStatement(old)
+	if err != nil {
+		return false
+	}
+	normalizedNew, err := NormalizePolicyStatement(new)
+	return err == nil && normalizedOld == normalizedNew
+}
+
+func tokenizePolicyStatement(statement string) ([]policyToken, error) {
+	var tokens []policyToken
+	runes := []rune(statement)
+	glued := false
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			glued = false
+			i++
+			continue
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quoted string at position %d", i+1)
+			}
+			tokens = append(tokens, policyToken{kind: policyQuoted, text: string(runes[i : end+1]), pos: i + 1, glued: glued})
+			i = end + 1
+		case r == '{' || r == '}' || r == ',' || r == '=':
+			tokens = append(tokens, policyToken{kind: policyPunct, text: string(r), pos: i + 1})
+			i++
+		case r == '!' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, policyToken{kind: policyPunct, text: "!=", pos: i + 1})
+			i += 2
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("{},='\"", runes[end]) &&
+				!(runes[end] == '!' && end+1 < len(runes) && runes[end+1] == '=') {
+				end++
+			}
+			tokens = append(tokens, policyToken{kind: policyWord, text: string(runes[i:end]), pos: i + 1, glued: glued})
+			i = end
+		}
+		glued = tokens[len(tokens)-1].kind != policyPunct
+	}
+	return tokens, nil
+}
+
+func (p *policyParser) peek() *policyToken {
+	if p.next < len(p.tokens) {
+		return &p.tokens[p.next]
+	}
+	return nil
+}
+
+// peekKeyword returns whether the next token is one of the keywords, case insensitively
+func (p *policyParser) peekKeyword(keywords ...string) bool {
+	t := p.peek()
+	if t == nil || t.kind != policyWord {
+		return false
+	}
+	for _, keyword := range keywords {
+		if strings.EqualFold(t.text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *policyParser) errorf(expected string) error {
+	if t := p.peek(); t != nil {
+		return fmt.Errorf("expected %s at position %d, found %q", expected, t.pos, t.text)
+	}
+	return fmt.Errorf("expected %s at the end of the statement", expected)
+}
+
+// keyword consumes one of the keywords and writes it in lower case
+func (p *policyParser) keyword(keywords ...string) (string, error) {
+	if !p.peekKeyword(keywords...) {
+		return "", p.errorf(strings.Join(keywords, " or "))
+	}
+	keyword := strings.ToLower(p.tokens[p.next].text)
+	p.next++
+	p.out = append(p.out, keyword)
+	return keyword, nil
+}
+
+func (p *policyParser) punct(text string) error {
+	if t := p.peek(); t == nil || t.kind != policyPunct || t.text != text {
+		return p.errorf(fmt.Sprintf("%q", text))
+	}
+	p.next++
+	p.out = append(p.out, text)
+	return nil
+}
+
+// name consumes a name made of the tokens glued together, e.g. Admins, 'Default'/'Admins' or Root:Child
+func (p *policyParser) name(expected string) (string, error) {
+	t := p.peek()
+	if t == nil || t.kind == policyPunct {
+		return "", p.errorf(expected)
+	}
+	var sb strings.Builder
+	sb.WriteString(t.text)
+	p.next++
+	for t = p.peek(); t != nil && t.glued && t.kind != policyPunct; t = p.peek() {
+		sb.WriteString(t.text)
+		p.next++
+	}
+	p.out = append(p.out, sb.String())
+	return sb.String(), nil
+}
+
+func (p *policyParser) ocid() (string, error) {
+	t := p.peek()
+	if t == nil || t.kind != policyWord || !policyOcidRe.MatchString(strings.ToLower(t.text)) {
+		return "", p.errorf("an OCID")
+	}
+	p.next++
+	p.out = append(p.out, strings.ToLower(t.text))
+	return strings.ToLower(t.text), nil
+}
+
+func (p *policyParser) parseStatement() error {
+	action := ""
+	for _, candidate := range policyStatementActions {
+		if p.peekKeyword(candidate) {
+			action = candidate
+		}
+	}
+	if action == "" {
+		return p.errorf(strings.Join(policyStatementActions, ", "))
+	}
+	p.next++
+	p.out = append(p.out, action)
+	p.stmt.Action = action
+
+	var err error
+	switch action {
+	case "Define":
+		err = p.parseDefine()
+	case "Admit":
+		if err = p.parseSubjects(); err == nil {
+			if _, err = p.keyword("of"); err == nil {
+				err = p.parseTenancy()
+			}
+		}
+		if err == nil {
+			err = p.parsePermission(false)
+		}
+	default:
+		if err = p.parseSubjects(); err == nil {
+			err = p.parsePermission(action == "Endorse")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if p.peek() != nil {
+		return p.errorf("the end of the statement")
+	}
+	return nil
+}
+
+func (p *policyParser) parseDefine() error {
+	kind := p.peek()
+	if kind == nil || kind.kind != policyWord || !policyDefineKinds[strings.ToLower(kind.text)] {
+		return p.errorf("tenancy, group or dynamic-group")
+	}
+	p.next++
+	p.out = append(p.out, strings.ToLower(kind.text))
+	if _, err := p.name("an alias"); err != nil {
+		return err
+	}
+	if _, err := p.keyword("as"); err != nil {
+		return err
+	}
+	_, err := p.ocid()
+	return err
+}
+
+func (p *policyParser) parseSubjects() error {
+	for {
+		if err := p.parseSubject(); err != nil {
+			return err
+		}
+		if t := p.peek(); t == nil || t.text != "," {
+			return nil
+		}
+		p.next++
+		p.out[len(p.out)-1] += ","
+	}
+}
+
+func (p *policyParser) parseSubject() error {
+	if p.peekKeyword("any-user", "any-group") {
+		kind, err := p.keyword("any-user", "any-group")
+		p.stmt.Subjects = append(p.stmt.Subjects, PolicySubject{Kind: kind})
+		return err
+	}
+	t := p.peek()
+	if t == nil || t.kind != policyWord || !policySubjectKinds[strings.ToLower(t.text)] {
+		return p.errorf("a subject such as group <name>, dynamic-group <name>, any-user or any-group")
+	}
+	kind := strings.ToLower(t.text)
+	p.next++
+	p.out = append(p.out, kind)
+
+	switch {
+	case kind == "resource":
+		// resource <resource type> <name>
+		resourceType, err := p.name("a resource type")
+		if err != nil {
+			return err
+		}
+		name, err := p.name("a resource name")
+		p.stmt.Subjects = append(p.stmt.Subjects, PolicySubject{Kind: kind, Name: resourceType + " " + name})
+		return err
+	case kind != "service" && p.peekKeyword("id"):
+		if _, err := p.keyword("id"); err != nil {
+			return err
+		}
+		id, err := p.ocid()
+		p.stmt.Subjects = append(p.stmt.Subjects, PolicySubject{Kind: kind, Id: id})
+		return err
+	default:
+		name, err := p.name(fmt.Sprintf("the name of the %s", kind))
+		p.stmt.Subjects = append(p.stmt.Subjects, PolicySubject{Kind: kind, Name: name})
+		return err
+	}
+}
+
+func (p *policyParser) parseTenancy() error {
+	if p.peekKeyword("any-tenancy") {
+		_, err := p.keyword("any-tenancy")
+		return err
+	}
+	if _, err := p.keyword("tenancy", "any-tenancy"); err != nil {
+		return err
+	}
+	_, err := p.name("the alias of the tenancy")
+	return err
+}
+
+// parsePermission parses to <verb> <resource type> in <location> [where <conditions>]
+func (p *policyParser) parsePermission(crossTenancy bool) error {
+	if _, err := p.keyword("to"); err != nil {
+		return err
+	}
+
+	if p.peekKeyword("associate") {
+		// to associate <resource type> in <location> with <resource type> in <location>
+		if _, err := p.keyword("associate"); err != nil {
+			return err
+		}
+		p.stmt.Verb = "associate"
+		if err := p.parseResourceLocation(crossTenancy, false); err != nil {
+			return err
+		}
+		if _, err := p.keyword("with"); err != nil {
+			return err
+		}
+		if err := p.parseResourceLocation(true, false); err != nil {
+			return err
+		}
+	} else {
+		permissions, err := p.parseVerb()
+		if err != nil {
+			return err
+		}
+		// The resource type is optional after a list of permissions
+		if err := p.parseResourceLocation(crossTenancy, permissions && p.peekKeyword("in")); err != nil {
+			return err
+		}
+	}
+
+	if p.peekKeyword("where") {
+		if _, err := p.keyword("where"); err != nil {
+			return err
+		}
+		start := len(p.out)
+		if err := p.parseCondition(); err != nil {
+			return err
+		}
+		p.stmt.Conditions = renderPolicyTokens(p.out[start:])
+	}
+	return nil
+}
+
+// parseVerb parses a verb or a list of permissions, and returns whether it is a list of permissions
+func (p *policyParser) parseVerb() (bool, error) {
+	if t := p.peek(); t != nil && t.text == "{" {
+		// {PERMISSION, ...}, the permissions are sorted as their order does not matter
+		p.next++
+		var permissions []string
+		for {
+			t := p.peek()
+			if t == nil || t.kind != policyWord || !policyPermissionRe.MatchString(strings.ToUpper(t.text)) {
+				return true, p.errorf("a permission such as VCN_READ")
+			}
+			p.next++
+			permissions = append(permissions, strings.ToUpper(t.text))
+			if t := p.peek(); t != nil && t.text == "," {
+				p.next++
+				continue
+			}
+			if t := p.peek(); t == nil || t.text != "}" {
+				return true, p.errorf(`"," or "}"`)
+			}
+			p.next++
+			break
+		}
+		sort.Strings(permissions)
+		p.out = append(p.out, "{"+strings.Join(permissions, ", ")+"}")
+		p.stmt.Permissions = permissions
+		return true, nil
+	}
+
+	t := p.peek()
+	if t == nil || t.kind != policyWord || !policyVerbs[strings.ToLower(t.text)] {
+		return false, p.errorf("a verb (inspect, read, use or manage) or a list of permissions")
+	}
+	p.next++
+	p.out = append(p.out, strings.ToLower(t.text))
+	p.stmt.Verb = strings.ToLower(t.text)
+	return false, nil
+}
+
+func (p *policyParser) parseResourceLocation(crossTenancy bool, noResourceType bool) error {
+	resourceType := ""
+	if !noResourceType {
+		t := p.peek()
+		if t == nil || t.kind != policyWord || !policyResourceTypeRe.MatchString(strings.ToLower(t.text)) {
+			return p.errorf("a resource type such as all-resources or instances")
+		}
+		p.next++
+		resourceType = strings.ToLower(t.text)
+		p.out = append(p.out, resourceType)
+	}
+
+	if _, err := p.keyword("in"); err != nil {
+		return err
+	}
+	location, err := p.parseLocation(crossTenancy)
+	if err != nil {
+		return err
+	}
+	if !p.locationSet {
+		p.stmt.ResourceType, p.stmt.Location, p.locationSet = resourceType, location, true
+	}
+	return nil
+}
+
+func (p *policyParser) parseLocation(crossTenancy bool) (PolicyLocation, error) {
+	switch {
+	case p.peekKeyword("compartment"):
+		if _, err := p.keyword("compartment"); err != nil {
+			return PolicyLocation{}, err
+		}
+		if p.peekKeyword("id") {
+			if _, err := p.keyword("id"); err != nil {
+				return PolicyLocation{}, err
+			}
+			id, err := p.ocid()
+			return PolicyLocation{Kind: "compartment", Id: id}, err
+		}
+		name, err := p.name("the name or the path of the compartment")
+		return PolicyLocation{Kind: "compartment", Name: name}, err
+	case p.peekKeyword("tenancy"):
+		if _, err := p.keyword("tenancy"); err != nil {
+			return PolicyLocation{}, err
+		}
+		// The tenancy of an endorse statement is the alias of the other tenancy
+		if crossTenancy && !p.peekKeyword("where", "with") && p.peek() != nil {
+			alias, err := p.name("the alias of the tenancy")
+			return PolicyLocation{Kind: "tenancy", Name: alias}, err
+		}
+		return PolicyLocation{Kind: "tenancy"}, nil
+	case crossTenancy && p.peekKeyword("any-tenancy"):
+		_, err := p.keyword("any-tenancy")
+		return PolicyLocation{Kind: "any-tenancy"}, err
+	default:
+		return PolicyLocation{}, p.errorf("compartment <name>, compartment id <ocid> or tenancy")
+	}
+}
+
+// parseCondition parses <variable> <operator> <value> or all|any {<condition>, ...}
+func (p *policyParser) parseCondition() error {
+	if p.peekKeyword("all", "any") {
+		if _, err := p.keyword("all", "any"); err != nil {
+			return err
+		}
+		if err := p.punct("{"); err != nil {
+			return err
+		}
+		for {
+			if err := p.parseCondition(); err != nil {
+				return err
+			}
+			if t := p.peek(); t != nil && t.text == "," {
+				p.next++
+				p.out[len(p.out)-1] += ","
+				continue
+			}
+			return p.punct("}")
+		}
+	}
+
+	if _, err := p.name("a condition such as request.user.id = '<ocid>'"); err != nil {
+		return err
+	}
+	t := p.peek()
+	if t == nil || !policyConditionOps[strings.ToLower(t.text)] {
+		return p.errorf("an operator (=, !=, before, after, between or in)")
+	}
+	operator := strings.ToLower(t.text)
+	p.next++
+	p.out = append(p.out, operator)
+
+	if _, err := p.name("a value"); err != nil {
+		return err
+	}
+	if operator == "between" {
+		if _, err := p.keyword("and"); err != nil {
+			return err
+		}
+		if _, err := p.name("a value"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderPolicyTokens joins the normalized tokens, without spaces inside the braces of the conditions
+func renderPolicyTokens(out []string) string {
+	var sb strings.Builder
+	for index, token := range out {
+		if index > 0 && token != "}" && !strings.HasSuffix(out[index-1], "{") {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(token)
+	}
+	return sb.String()
+}
@@ -20,6 +20,7 @@ func RegisterDatasource() {
 	tfresource.RegisterDatasource("oci_identity_domain", IdentityDomainDataSource())
 	tfresource.RegisterDatasource("oci_identity_domains", IdentityDomainsDataSource())
 	tfresource.RegisterDatasource("oci_identity_dynamic_groups", IdentityDynamicGroupsDataSource())
+	tfresource.RegisterDatasource("oci_identity_effective_permissions", IdentityEffectivePermissionsDataSource())
 	tfresource.RegisterDatasource("oci_identity_fault_domains", IdentityFaultDomainsDataSource())
 	tfresource.RegisterDatasource("oci_identity_group", IdentityGroupDataSource())
 	tfresource.RegisterDatasource("oci_identity_groups", IdentityGroupsDataSource())
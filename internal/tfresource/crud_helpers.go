@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -145,10 +146,17 @@ type workReqClient interface {
 func waitForStateRefreshForHybridPolling(workRequestClient workReqClient, workRequestIds *string, entityType string, action oci_work_requests.WorkRequestResourceActionTypeEnum,
 	disableFoundRetries bool, sync StatefulResource, timeout time.Duration, operationName string, pending, target []string) error {
 	// TODO: try to move this onto sync
+	refresh := stateRefreshFuncVar(sync)
+	workRequestsProgress := newWorkRequestsProgress(workRequestClient, workRequestIds)
 	stateConf := &resource.StateChangeConf{
 		Pending: pending,
 		Target:  target,
-		Refresh: stateRefreshFuncVar(sync),
+		Refresh: func() (interface{}, string, error) {
+			for _, progress := range workRequestsProgress {
+				progress.poll()
+			}
+			return refresh()
+		},
 		Timeout: timeout,
 	}
 
@@ -184,6 +192,9 @@ func ResourceRefreshForHybridPolling(workRequestClient workReqClient, workReques
 
 	// ID is required for state refresh
 	d.SetId(sync.ID())
+	if workRequestIds != nil {
+		setPendingCreate(d, *workRequestIds, entityType)
+	}
 
 	if stateful, ok := sync.(StatefullyCreatedResource); ok {
 		if e := waitForStateRefreshForHybridPollingVar(workRequestClient, workRequestIds, entityType, action, disableFoundRetries, stateful, d.Timeout(schema.TimeoutCreate), "creation", stateful.CreatedPending(), stateful.CreatedTarget()); e != nil {
@@ -203,6 +214,7 @@ func ResourceRefreshForHybridPolling(workRequestClient workReqClient, workReques
 	}
 
 	d.SetId(sync.ID())
+	clearPendingCreate(d)
 	if e := sync.SetData(); e != nil {
 		return e
 	}
@@ -219,6 +231,9 @@ func ResourceRefreshForHybridPollingPreserveStateOnFailures(workRequestClient wo
 
 	// ID is required for state refresh
 	d.SetId(sync.ID())
+	if workRequestIds != nil {
+		setPendingCreate(d, *workRequestIds, entityType)
+	}
 
 	if stateful, ok := sync.(StatefullyCreatedResource); ok {
 		if e := waitForStateRefreshForHybridPollingVar(workRequestClient, workRequestIds, entityType, action, disableFoundRetries, stateful, d.Timeout(schema.TimeoutCreate), "creation", stateful.CreatedPending(), stateful.CreatedTarget()); e != nil {
@@ -232,6 +247,7 @@ func ResourceRefreshForHybridPollingPreserveStateOnFailures(workRequestClient wo
 	}
 
 	d.SetId(sync.ID())
+	clearPendingCreate(d)
 	if e := sync.SetData(); e != nil {
 		return e
 	}
@@ -265,7 +281,11 @@ func ResourceRefreshForHybridPollingOnDeletePreserveStateOnFailures(workRequestC
 }
 
 func CreateResourceUsingHybridPolling(sync ResourceCreator) error {
-	if e := sync.Create(); e != nil {
+	var d schemaResourceData
+	if resourceData, ok := crudFieldValue(sync, resourceDataFieldName).(*schema.ResourceData); ok && resourceData != nil {
+		d = resourceData
+	}
+	if e := withCreateRetryToken(d, sync, sync.Create); e != nil {
 		return HandleErrorVar(sync, e)
 	}
 
@@ -280,7 +300,12 @@ func CreateResource(d schemaResourceData, sync ResourceCreator) error {
 		}
 	}
 
-	if e := sync.Create(); e != nil {
+	if e := withCreateRetryToken(d, sync, sync.Create); e != nil {
+		var workRequestWaitErr *WorkRequestWaitError
+		if errors.As(e, &workRequestWaitErr) {
+			// The resource may still be created on the service side, keep track of it so that the next refresh resumes waiting
+			setPendingCreate(d, workRequestWaitErr.WorkRequestId, workRequestWaitErr.EntityType)
+		}
 		return HandleError(sync, e)
 	}
 
@@ -316,6 +341,12 @@ func CreateResource(d schemaResourceData, sync ResourceCreator) error {
 }
 
 func ReadResource(sync ResourceReader) error {
+	if voided, e := resumePendingCreate(sync); e != nil {
+		return HandleError(sync, e)
+	} else if voided {
+		return nil
+	}
+
 	if e := sync.Get(); e != nil {
 		log.Printf("ERROR IN GET: %v\n", e.Error())
 		handleMissingResourceError(sync, &e)
@@ -1007,6 +1038,7 @@ func WaitForWorkRequest(workRequestClient workReqClient, workRequestId *string,
 	retryPolicy.ShouldRetryOperation = workRequestShouldRetryFunc(timeout)
 
 	response := oci_work_requests.GetWorkRequestResponse{}
+	progress := newWorkRequestProgress(workRequestClient, *workRequestId)
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{
 			string(oci_work_requests.WorkRequestStatusInProgress),
@@ -1028,6 +1060,9 @@ func WaitForWorkRequest(workRequestClient workReqClient, workRequestId *string,
 					},
 				})
 			wr := &response.WorkRequest
+			if err == nil {
+				progress.report(wr)
+			}
 			return wr, string(wr.Status), err
 		},
 		Timeout: timeout,
@@ -1045,7 +1080,7 @@ func WaitForWorkRequest(workRequestClient workReqClient, workRequestId *string,
 			}
 		}
 
-		return identifier, e
+		return identifier, &WorkRequestWaitError{WorkRequestId: *workRequestId, EntityType: entityType, Err: e}
 	}
 
 	// The work request response contains an array of objects that finished the operation
@@ -1135,7 +1170,11 @@ func getWorkRequestErrors(workRequestClient workReqClient, workRequestId *string
 	}
 	errorMessage := strings.Join(allErrs, "\n")
 
-	workRequestErr := fmt.Errorf("work request did not succeed, workId: %s, entity: %s, action: %s. Message: %s", *workRequestId, entityType, action, errorMessage)
+	workRequestErr := &WorkRequestFailedError{
+		WorkRequestId: *workRequestId,
+		LogLines:      lastWorkRequestLogLines(workRequestClient, *workRequestId),
+		Message:       fmt.Sprintf("work request did not succeed, workId: %s, entity: %s, action: %s. Message: %s", *workRequestId, entityType, action, errorMessage),
+	}
 
 	return workRequestErr
 }
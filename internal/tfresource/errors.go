@@ -5,6 +5,7 @@ package tfresource
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -51,6 +52,8 @@ type customError struct {
 	VersionError            string            `json:"version_error"`
 	ResourceDocs            string            `json:"resource_docs"`
 	SdkApiDocs              string            `json:"sdk_api_docs"`
+	WorkRequestID           string            `json:"work_request_id,omitempty"`
+	WorkRequestLogs         []string          `json:"work_request_logs,omitempty"`
 	JsonError               string            `json:"-"`
 }
 
@@ -101,6 +104,11 @@ func newCustomError(sync interface{}, err error) error {
 			Service:       getServiceName(sync),
 			ResourceOCID:  getResourceOCID(sync),
 		}
+		var workRequestErr *WorkRequestFailedError
+		if errors.As(err, &workRequestErr) {
+			tfError.WorkRequestID = workRequestErr.WorkRequestId
+			tfError.WorkRequestLogs = workRequestErr.LogLines
+		}
 	} else {
 		// Terraform error return as is
 		return err
@@ -162,13 +170,21 @@ func (tfE customError) Error() error {
 			"Suggestion: %s\n",
 			tfE.ErrorCodeName, tfE.VersionError, tfE.Service, tfE.Message, tfE.ResourceOCID, tfE.Suggestion)
 	case WorkRequestError:
+		workRequestDetails := ""
+		if tfE.WorkRequestID != "" {
+			workRequestDetails = fmt.Sprintf("Work Request ID: %s \n", tfE.WorkRequestID)
+		}
+		if len(tfE.WorkRequestLogs) > 0 {
+			workRequestDetails += fmt.Sprintf("Work Request Logs: \n%s \n", strings.Join(tfE.WorkRequestLogs, "\n"))
+		}
 		finalError = fmt.Errorf("%s \n"+
 			"%s \n"+
 			"Service: %s \n"+
 			"Error Message: %s \n"+
 			"Resource OCID: %s \n"+
+			"%s"+
 			"Suggestion: %s\n",
-			tfE.ErrorCodeName, tfE.VersionError, tfE.Service, tfE.Message, tfE.ResourceOCID, tfE.Suggestion)
+			tfE.ErrorCodeName, tfE.VersionError, tfE.Service, tfE.Message, tfE.ResourceOCID, workRequestDetails, tfE.Suggestion)
 	default:
 		finalError = fmt.Errorf(tfE.Message)
 	}
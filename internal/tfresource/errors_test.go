@@ -217,6 +217,16 @@ func TestUnitHandleError(t *testing.T) {
 	response = HandleError(temp, mockError)
 	assert.Contains(t, response.Error(), "Work Request error")
 
+	//Failed Work Request Case
+	workRequestErr := &WorkRequestFailedError{
+		WorkRequestId: "ocid1.workrequest.oc1..aaaa",
+		LogLines:      []string{"Provisioning the VM cluster", "Provisioning failed"},
+		Message:       "work request did not succeed, workId: ocid1.workrequest.oc1..aaaa, entity: vmcluster, action: CREATED. Message: failed",
+	}
+	response = HandleError(temp, workRequestErr)
+	assert.Contains(t, response.Error(), "Work Request ID: ocid1.workrequest.oc1..aaaa")
+	assert.Contains(t, response.Error(), "Provisioning the VM cluster\nProvisioning failed")
+
 	//Unknown Error case
 	mockError = &MockError{"Unexpected Error"}
 	response = HandleError(temp, mockError)
@@ -0,0 +1,162 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package tfresource
+
+import (
+	"errors"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	oci_work_requests "github.com/oracle/oci-go-sdk/v65/workrequests"
+)
+
+const (
+	pendingCreateAttrName         = "pending_create_work_request"
+	pendingCreateWorkRequestIdKey = "id"
+	pendingCreateEntityTypeKey    = "entity_type"
+	resourceDataFieldName         = "D"
+	workRequestClientFieldName    = "WorkRequestClient"
+)
+
+// WorkRequestWaitError is returned when the wait on a work request did not complete, e.g. when the timeout is reached
+type WorkRequestWaitError struct {
+	WorkRequestId string
+	EntityType    string
+	Err           error
+}
+
+func (e *WorkRequestWaitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WorkRequestWaitError) Unwrap() error {
+	return e.Err
+}
+
+type pendingCreateResourceData interface {
+	Id() string
+	SetId(string)
+	GetOk(string) (interface{}, bool)
+	Set(string, interface{}) error
+}
+
+// AddPendingCreateSupport adds the computed attribute that keeps track of a creation still in progress on the service side,
+// so that an interrupted create lands in the state and is resumed by the next refresh
+func AddPendingCreateSupport(resource *schema.Resource) {
+	if resource == nil || resource.Schema == nil || resource.Create == nil {
+		return
+	}
+	if _, ok := resource.Schema[pendingCreateAttrName]; ok {
+		return
+	}
+
+	resource.Schema[pendingCreateAttrName] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The work request of a creation that did not complete while Terraform was waiting on it. The next refresh resumes waiting on it instead of creating the resource again.",
+	}
+}
+
+// setPendingCreate records the work request of a creation before waiting on it. The work request ID is used as the
+// resource ID until the service returns the identifier of the resource.
+func setPendingCreate(d schemaResourceData, workRequestIds string, entityType string) {
+	pendingCreateData, ok := d.(pendingCreateResourceData)
+	if !ok || workRequestIds == "" {
+		return
+	}
+	if pendingCreateData.Id() == "" {
+		pendingCreateData.SetId(workRequestIds)
+	}
+	if err := pendingCreateData.Set(pendingCreateAttrName, map[string]interface{}{
+		pendingCreateWorkRequestIdKey: workRequestIds,
+		pendingCreateEntityTypeKey:    entityType,
+	}); err != nil {
+		log.Printf("[DEBUG] unable to record the pending creation of work request %s: %v", workRequestIds, err)
+	}
+}
+
+func clearPendingCreate(d schemaResourceData) {
+	pendingCreateData, ok := d.(pendingCreateResourceData)
+	if !ok {
+		return
+	}
+	if _, ok := pendingCreateData.GetOk(pendingCreateAttrName); !ok {
+		return
+	}
+	if err := pendingCreateData.Set(pendingCreateAttrName, map[string]interface{}{}); err != nil {
+		log.Printf("[DEBUG] unable to clear the pending creation: %v", err)
+	}
+}
+
+func getPendingCreate(d pendingCreateResourceData) (workRequestIds string, entityType string) {
+	pendingCreate, ok := d.GetOk(pendingCreateAttrName)
+	if !ok {
+		return "", ""
+	}
+	pendingCreateMap, ok := pendingCreate.(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	workRequestIds, _ = pendingCreateMap[pendingCreateWorkRequestIdKey].(string)
+	entityType, _ = pendingCreateMap[pendingCreateEntityTypeKey].(string)
+	return workRequestIds, entityType
+}
+
+// resumePendingCreate waits on the work request of a creation that was interrupted. It returns true when the creation
+// failed and the resource was removed from the state, so that it is created again on the next apply.
+func resumePendingCreate(sync ResourceReader) (bool, error) {
+	d, ok := crudFieldValue(sync, resourceDataFieldName).(*schema.ResourceData)
+	if !ok || d == nil {
+		return false, nil
+	}
+	workRequestIds, entityType := getPendingCreate(d)
+	if workRequestIds == "" {
+		return false, nil
+	}
+
+	log.Printf("[INFO] resuming the creation of %s, waiting on work request %s", d.Id(), workRequestIds)
+	if workRequestClient, ok := crudFieldValue(sync, workRequestClientFieldName).(workReqClient); ok && !isNilWorkRequestClient(workRequestClient) {
+		identifier, err := WaitForWorkRequestWithErrorHandling(workRequestClient, &workRequestIds, entityType, oci_work_requests.WorkRequestResourceActionTypeCreated, d.Timeout(schema.TimeoutCreate), false)
+		if identifier != nil {
+			d.SetId(*identifier)
+		}
+		if err != nil {
+			var workRequestErr *WorkRequestFailedError
+			if errors.As(err, &workRequestErr) {
+				log.Printf("[WARN] the creation of %s did not succeed, removing it from the state: %v", d.Id(), err)
+				d.SetId("")
+				return true, nil
+			}
+			return false, err
+		}
+	} else if stateful, ok := sync.(StatefullyCreatedResource); ok {
+		if err := waitForStateRefreshVar(stateful, d.Timeout(schema.TimeoutCreate), "creation", stateful.CreatedPending(), stateful.CreatedTarget()); err != nil {
+			return false, err
+		}
+	}
+
+	clearPendingCreate(d)
+	return false, nil
+}
+
+// crudFieldValue returns the value of a field of the CRUD struct, e.g. its resource data or work request client
+func crudFieldValue(sync interface{}, name string) interface{} {
+	value := reflect.ValueOf(sync)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	field := value.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return nil
+	}
+	return field.Interface()
+}
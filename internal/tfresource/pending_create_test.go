@@ -0,0 +1,141 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package tfresource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	oci_work_requests "github.com/oracle/oci-go-sdk/v65/workrequests"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockFailedWorkRequestClient struct {
+	mockWorkRequestClient
+}
+
+func (client *mockFailedWorkRequestClient) GetWorkRequest(_ context.Context, _ oci_work_requests.GetWorkRequestRequest) (oci_work_requests.GetWorkRequestResponse, error) {
+	return oci_work_requests.GetWorkRequestResponse{WorkRequest: oci_work_requests.WorkRequest{Status: oci_work_requests.WorkRequestStatusFailed}}, nil
+}
+
+type pendingCreateResourceCrud struct {
+	BaseCrud
+	WorkRequestClient workReqClient
+	getCalls          int
+}
+
+func (s *pendingCreateResourceCrud) Get() error {
+	s.getCalls++
+	return nil
+}
+
+func (s *pendingCreateResourceCrud) SetData() error {
+	return nil
+}
+
+func pendingCreateTestResource() *schema.Resource {
+	resource := &schema.Resource{
+		Create: func(*schema.ResourceData, interface{}) error { return nil },
+		Read:   func(*schema.ResourceData, interface{}) error { return nil },
+		Delete: func(*schema.ResourceData, interface{}) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"display_name": {Type: schema.TypeString, Optional: true, ForceNew: true},
+		},
+	}
+	AddPendingCreateSupport(resource)
+	return resource
+}
+
+func TestUnitAddPendingCreateSupport(t *testing.T) {
+	resource := pendingCreateTestResource()
+	if assert.Contains(t, resource.Schema, pendingCreateAttrName) {
+		assert.True(t, resource.Schema[pendingCreateAttrName].Computed)
+	}
+	assert.NoError(t, resource.InternalValidate(nil, true))
+
+	withoutCreate := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	AddPendingCreateSupport(withoutCreate)
+	assert.NotContains(t, withoutCreate.Schema, pendingCreateAttrName)
+}
+
+func TestUnitSetPendingCreate(t *testing.T) {
+	d := pendingCreateTestResource().TestResourceData()
+
+	setPendingCreate(d, "ocid1.workrequest.oc1..aaaa", "instance")
+	assert.Equal(t, "ocid1.workrequest.oc1..aaaa", d.Id())
+	workRequestIds, entityType := getPendingCreate(d)
+	assert.Equal(t, "ocid1.workrequest.oc1..aaaa", workRequestIds)
+	assert.Equal(t, "instance", entityType)
+
+	d.SetId("ocid1.instance.oc1..aaaa")
+	setPendingCreate(d, "ocid1.workrequest.oc1..bbbb", "instance")
+	assert.Equal(t, "ocid1.instance.oc1..aaaa", d.Id())
+
+	clearPendingCreate(d)
+	workRequestIds, _ = getPendingCreate(d)
+	assert.Empty(t, workRequestIds)
+}
+
+func resetWorkRequestVars() {
+	WaitForWorkRequestVar = WaitForWorkRequest
+	getWorkRequestErrorsVar = getWorkRequestErrors
+	waitForStateRefreshVar = WaitForStateRefresh
+}
+
+func TestUnitResumePendingCreate(t *testing.T) {
+	resetWorkRequestVars()
+	type testFormat struct {
+		name              string
+		workRequestClient workReqClient
+		workRequestIds    string
+		expectedId        string
+		voided            bool
+		err               bool
+	}
+	tests := []testFormat{
+		{"no pending create", &mockWorkRequestClient{}, "", "ocid1.workrequest.oc1..1", false, false},
+		{"pending create succeeded", &mockWorkRequestClient{}, "1", "oci", false, false},
+		{"pending create failed", &mockFailedWorkRequestClient{}, "1", "", true, false},
+		{"pending create did not complete", &mockWorkRequestClient{}, "4", "ocid1.workrequest.oc1..1", false, true},
+	}
+	for _, test := range tests {
+		t.Logf("Running %s", test.name)
+		d := pendingCreateTestResource().TestResourceData()
+		d.SetId("ocid1.workrequest.oc1..1")
+		if test.workRequestIds != "" {
+			setPendingCreate(d, test.workRequestIds, "default")
+		}
+		sync := &pendingCreateResourceCrud{BaseCrud: BaseCrud{D: d}, WorkRequestClient: test.workRequestClient}
+
+		voided, err := resumePendingCreate(sync)
+		assert.Equal(t, test.err, err != nil, fmt.Sprintf("%v", err))
+		assert.Equal(t, test.voided, voided)
+		assert.Equal(t, test.expectedId, d.Id())
+		if !test.err && !test.voided {
+			workRequestIds, _ := getPendingCreate(d)
+			assert.Empty(t, workRequestIds)
+		}
+	}
+}
+
+func TestUnitReadResourcePendingCreate(t *testing.T) {
+	resetWorkRequestVars()
+	d := pendingCreateTestResource().TestResourceData()
+	setPendingCreate(d, "1", "default")
+	sync := &pendingCreateResourceCrud{BaseCrud: BaseCrud{D: d}, WorkRequestClient: &mockWorkRequestClient{}}
+
+	assert.NoError(t, ReadResource(sync))
+	assert.Equal(t, "oci", d.Id())
+	assert.Equal(t, 1, sync.getCalls)
+
+	d = pendingCreateTestResource().TestResourceData()
+	setPendingCreate(d, "1", "default")
+	sync = &pendingCreateResourceCrud{BaseCrud: BaseCrud{D: d}, WorkRequestClient: &mockFailedWorkRequestClient{}}
+
+	assert.NoError(t, ReadResource(sync))
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, 0, sync.getCalls)
+}
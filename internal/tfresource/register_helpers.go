@@ -5,13 +5,70 @@ import (
 	"github.com/oracle/terraform-provider-oci/internal/globalvar"
 )
 
+const regionAttrName = "region"
+
+// ClientsForRegionVar returns the provider clients scoped to another region. It is set by the client package.
+var ClientsForRegionVar func(m interface{}, region string) (interface{}, error)
+
 func RegisterResource(name string, resourceSchema *schema.Resource) {
 	if globalvar.OciResources == nil {
 		globalvar.OciResources = make(map[string]*schema.Resource)
 	}
+	AddTagsDiffSuppress(resourceSchema)
+	AddDefaultTagsCustomizeDiff(resourceSchema)
+	AddRegionSupport(resourceSchema)
+	AddPendingCreateSupport(resourceSchema)
 	globalvar.OciResources[name] = resourceSchema
 }
 
+// AddRegionSupport adds an optional `region` argument to the resource. When it is set, the CRUD functions of the
+// resource get clients for that region instead of the provider region, so that a single provider can manage
+// resources in several regions. Resources that already have a top level `region` attribute are left as they are.
+func AddRegionSupport(resource *schema.Resource) {
+	if resource == nil || resource.Schema == nil || resource.Create == nil || resource.Read == nil || resource.Delete == nil {
+		return
+	}
+	if _, ok := resource.Schema[regionAttrName]; ok {
+		return
+	}
+
+	resource.Schema[regionAttrName] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The region to manage the resource in. Defaults to the provider region.",
+	}
+
+	create, read, update, delete := resource.Create, resource.Read, resource.Update, resource.Delete
+	resource.Create = func(d *schema.ResourceData, m interface{}) error {
+		return withRegionalClients(d, m, create)
+	}
+	resource.Read = func(d *schema.ResourceData, m interface{}) error {
+		return withRegionalClients(d, m, read)
+	}
+	if update != nil {
+		resource.Update = func(d *schema.ResourceData, m interface{}) error {
+			return withRegionalClients(d, m, update)
+		}
+	}
+	resource.Delete = func(d *schema.ResourceData, m interface{}) error {
+		return withRegionalClients(d, m, delete)
+	}
+}
+
+func withRegionalClients(d *schema.ResourceData, m interface{}, crudFn func(*schema.ResourceData, interface{}) error) error {
+	region, ok := d.GetOk(regionAttrName)
+	if !ok || ClientsForRegionVar == nil {
+		return crudFn(d, m)
+	}
+
+	regionalClients, err := ClientsForRegionVar(m, region.(string))
+	if err != nil {
+		return err
+	}
+	return crudFn(d, regionalClients)
+}
+
 func RegisterDatasource(name string, datasourceSchema *schema.Resource) {
 	if globalvar.OciDatasources == nil {
 		globalvar.OciDatasources = make(map[string]*schema.Resource)
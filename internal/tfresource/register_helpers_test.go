@@ -42,3 +42,71 @@ func TestUnitRegisterHelper(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitAddRegionSupport(t *testing.T) {
+	var receivedMeta interface{}
+	crudFn := func(d *schema.ResourceData, m interface{}) error {
+		receivedMeta = m
+		return nil
+	}
+	testResource := &schema.Resource{
+		Create: crudFn,
+		Read:   crudFn,
+		Delete: crudFn,
+		Schema: map[string]*schema.Schema{
+			"display_name": {Type: schema.TypeString, Optional: true, ForceNew: true},
+		},
+	}
+	AddRegionSupport(testResource)
+	if _, ok := testResource.Schema[regionAttrName]; !ok {
+		t.Fatalf("AddRegionSupport() did not add the %s attribute", regionAttrName)
+	}
+
+	prevClientsForRegion := ClientsForRegionVar
+	defer func() { ClientsForRegionVar = prevClientsForRegion }()
+	ClientsForRegionVar = func(m interface{}, region string) (interface{}, error) {
+		return m.(string) + "/" + region, nil
+	}
+
+	tests := []struct {
+		name   string
+		region string
+		want   interface{}
+	}{
+		{
+			name:   "Test provider clients are used when region is not set",
+			region: "",
+			want:   "provider",
+		},
+		{
+			name:   "Test regional clients are used when region is set",
+			region: "us-ashburn-1",
+			want:   "provider/us-ashburn-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResource.TestResourceData()
+			d.Set(regionAttrName, tt.region)
+			if err := testResource.Create(d, "provider"); err != nil {
+				t.Fatalf("Create() returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(receivedMeta, tt.want) {
+				t.Errorf("Create() got meta %v, want %v", receivedMeta, tt.want)
+			}
+		})
+	}
+
+	existingRegionResource := &schema.Resource{
+		Create: crudFn,
+		Read:   crudFn,
+		Delete: crudFn,
+		Schema: map[string]*schema.Schema{
+			regionAttrName: {Type: schema.TypeString, Computed: true},
+		},
+	}
+	AddRegionSupport(existingRegionResource)
+	if existingRegionResource.Schema[regionAttrName].Optional {
+		t.Errorf("AddRegionSupport() should not override an existing %s attribute", regionAttrName)
+	}
+}
@@ -57,9 +57,29 @@ var serviceRetryPolicyFnMap = map[string]getRetryPolicyFunc{
 var ShortRetryTime = 2 * time.Minute
 var LongRetryTime = 10 * time.Minute
 var ConfiguredRetryDuration *time.Duration
+
 var isServiceErrorVar = oci_common.IsServiceError
 var isErrorAffectedByEventualConsistency = oci_common.IsErrorAffectedByEventualConsistency
 
+// ServiceRetryConfigurations holds the retry behavior from the provider `retry` blocks, keyed by service name.
+// The DefaultRetryConfigurationService entry applies to services that don't have their own.
+var ServiceRetryConfigurations map[string]*RetryConfiguration
+
+const DefaultRetryConfigurationService = "default"
+
+type RetryConfiguration struct {
+	// Maximum duration to keep retrying a retriable error; nil keeps the provider default
+	MaxDuration *time.Duration
+	// The backoff before attempt n is BackoffBase*2*n^2, capped at BackoffCap
+	BackoffBase time.Duration
+	BackoffCap  time.Duration
+	// Randomize the backoff between minRetryBackoff and the computed backoff to avoid clustering
+	Jitter bool
+	// When any is set, only errors with these HTTP status codes or service error codes are retried
+	RetryableStatusCodes  []int
+	RetryableServiceCodes []string
+}
+
 func init() {
 	rand.Seed(time.Now().UnixNano())
 }
@@ -82,6 +102,9 @@ func getRetryBackoffDurationWithExpectedRetryDurationFn(response oci_common.OCIO
 
 	// Jitter the backoff time. The actual backoff time might be anywhere within the minimum and quadratic backoff time to avoid clustering.
 	backoffDuration := time.Duration(rand.Int63n(int64(retryBackoffRange+1))) + minRetryBackoff
+	if retryConfiguration := getServiceRetryConfiguration(service); retryConfiguration != nil {
+		backoffDuration = retryConfiguration.backoffDuration(response.AttemptNumber)
+	}
 
 	// If we are about to exceed the retry duration; then reduce the backoff so that next attempt happens roughly when
 	// the entire retry duration is supposed to expire. Jitter is necessary again to avoid clustering.
@@ -103,6 +126,14 @@ func GetElapsedRetryDuration(firstAttemptTime time.Time) time.Duration {
 }
 
 func getExpectedRetryDuration(response oci_common.OCIOperationResponse, disableNotFoundRetries bool, service string, optionals ...interface{}) time.Duration {
+	expectedRetryDuration := getServiceExpectedRetryDuration(response, disableNotFoundRetries, service, optionals...)
+	if retryConfiguration := getServiceRetryConfiguration(service); retryConfiguration != nil {
+		return retryConfiguration.expectedRetryDuration(response, expectedRetryDuration)
+	}
+	return expectedRetryDuration
+}
+
+func getServiceExpectedRetryDuration(response oci_common.OCIOperationResponse, disableNotFoundRetries bool, service string, optionals ...interface{}) time.Duration {
 	// Get the override retry duration function if it exists. This gives the most granular control over what value to return, and is passed
 	// into GetRetryPolicy function as an optional argument to override retry durations on a per API basis.
 	if len(optionals) > 0 {
@@ -120,6 +151,94 @@ func getExpectedRetryDuration(response oci_common.OCIOperationResponse, disableN
 	return GetDefaultExpectedRetryDuration(response, disableNotFoundRetries)
 }
 
+func getServiceRetryConfiguration(service string) *RetryConfiguration {
+	if len(ServiceRetryConfigurations) == 0 {
+		return nil
+	}
+	if retryConfiguration, ok := ServiceRetryConfigurations[NormalizeRetryServiceName(service)]; ok {
+		return retryConfiguration
+	}
+	return ServiceRetryConfigurations[DefaultRetryConfigurationService]
+}
+
+// NormalizeRetryServiceName makes service names in the `retry` block match the ones used by the resources,
+// e.g. "objectstorage" and "object_storage" are the same service
+func NormalizeRetryServiceName(service string) string {
+	return strings.ReplaceAll(strings.ToLower(service), "_", "")
+}
+
+func (c *RetryConfiguration) expectedRetryDuration(response oci_common.OCIOperationResponse, expectedRetryDuration time.Duration) time.Duration {
+	if len(c.RetryableStatusCodes) > 0 || len(c.RetryableServiceCodes) > 0 {
+		if !c.isRetryable(response) {
+			return 0
+		}
+		if c.MaxDuration != nil {
+			return *c.MaxDuration
+		}
+		if expectedRetryDuration == 0 {
+			return ShortRetryTime
+		}
+		return expectedRetryDuration
+	}
+
+	if expectedRetryDuration > 0 && c.MaxDuration != nil {
+		return *c.MaxDuration
+	}
+	return expectedRetryDuration
+}
+
+func (c *RetryConfiguration) isRetryable(response oci_common.OCIOperationResponse) bool {
+	if IsNetworkError(response.Error) {
+		return true
+	}
+	if response.Response != nil && response.Response.HTTPResponse() != nil {
+		statusCode := response.Response.HTTPResponse().StatusCode
+		if statusCode >= 200 && statusCode < 300 {
+			return false
+		}
+		for _, retryableStatusCode := range c.RetryableStatusCodes {
+			if statusCode == retryableStatusCode {
+				return true
+			}
+		}
+	}
+	if serviceError, ok := isServiceErrorVar(response.Error); ok {
+		for _, retryableServiceCode := range c.RetryableServiceCodes {
+			if strings.EqualFold(serviceError.GetCode(), retryableServiceCode) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *RetryConfiguration) backoffDuration(attempt uint) time.Duration {
+	backoffBase := c.BackoffBase
+	if backoffBase <= 0 {
+		backoffBase = time.Second
+	}
+	backoffCap := c.BackoffCap
+	if backoffCap <= 0 {
+		backoffCap = time.Duration(2*quadraticBackoffCap*quadraticBackoffCap) * time.Second
+	}
+
+	backoff := backoffCap
+	// Avoid overflowing for large attempt numbers, the cap is reached long before
+	if attempt < 1<<16 {
+		if quadraticBackoff := backoffBase * time.Duration(2*attempt*attempt); quadraticBackoff > 0 && quadraticBackoff < backoffCap {
+			backoff = quadraticBackoff
+		}
+	}
+	if backoff < minRetryBackoff {
+		backoff = minRetryBackoff
+	}
+
+	if !c.Jitter {
+		return backoff
+	}
+	return time.Duration(rand.Int63n(int64(backoff-minRetryBackoff+1))) + minRetryBackoff
+}
+
 func GetDefaultExpectedRetryDuration(response oci_common.OCIOperationResponse, disableNotFoundRetries bool) time.Duration {
 	defaultRetryTime := ShortRetryTime
 
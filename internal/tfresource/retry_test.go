@@ -892,3 +892,77 @@ func TestUnit_IsNetworkError(t *testing.T) {
 		})
 	}
 }
+
+// issue-routing-tag: terraform/default
+func TestUnitServiceRetryConfiguration(t *testing.T) {
+	ShortRetryTime = 1 * time.Second
+	LongRetryTime = 2 * time.Second
+	ConfiguredRetryDuration = nil
+
+	identityMaxDuration := 20 * time.Minute
+	defaultMaxDuration := time.Duration(0)
+	ServiceRetryConfigurations = map[string]*RetryConfiguration{
+		NormalizeRetryServiceName(identityService): {
+			MaxDuration:          &identityMaxDuration,
+			RetryableStatusCodes: []int{429},
+		},
+		DefaultRetryConfigurationService: {
+			MaxDuration: &defaultMaxDuration,
+		},
+	}
+	defer func() { ServiceRetryConfigurations = nil }()
+
+	type testFormat struct {
+		name     string
+		service  string
+		response common.OCIOperationResponse
+		output   time.Duration
+	}
+	tests := []testFormat{
+		{
+			name:     "Test retryable status code for a configured service",
+			service:  identityService,
+			response: common.OCIOperationResponse{Response: TestOCIResponse{statusCode: 429}},
+			output:   identityMaxDuration,
+		},
+		{
+			name:     "Test status code that is not retryable for a configured service",
+			service:  identityService,
+			response: common.OCIOperationResponse{Response: TestOCIResponse{statusCode: 500}},
+			output:   0,
+		},
+		{
+			name:     "Test default configuration fails fast",
+			service:  databaseService,
+			response: common.OCIOperationResponse{Response: TestOCIResponse{statusCode: 429}},
+			output:   0,
+		},
+	}
+	for _, test := range tests {
+		t.Logf("Running %s", test.name)
+		if res := getExpectedRetryDuration(test.response, true, test.service); res != test.output {
+			t.Errorf("Output %s not equal to expected %s", res, test.output)
+		}
+	}
+}
+
+// issue-routing-tag: terraform/default
+func TestUnitRetryConfigurationBackoffDuration(t *testing.T) {
+	retryConfiguration := &RetryConfiguration{BackoffBase: 2 * time.Second, BackoffCap: 30 * time.Second}
+	assert.Equal(t, 4*time.Second, retryConfiguration.backoffDuration(1))
+	assert.Equal(t, 16*time.Second, retryConfiguration.backoffDuration(2))
+	assert.Equal(t, 30*time.Second, retryConfiguration.backoffDuration(3))
+	assert.Equal(t, 30*time.Second, retryConfiguration.backoffDuration(1<<20))
+
+	retryConfiguration.Jitter = true
+	for attempt := uint(1); attempt < 10; attempt++ {
+		backoff := retryConfiguration.backoffDuration(attempt)
+		assert.True(t, backoff >= minRetryBackoff && backoff <= 30*time.Second, "unexpected backoff %v for attempt %v", backoff, attempt)
+	}
+}
+
+// issue-routing-tag: terraform/default
+func TestUnitNormalizeRetryServiceName(t *testing.T) {
+	assert.Equal(t, NormalizeRetryServiceName("objectstorage"), NormalizeRetryServiceName(objectstorageService))
+	assert.Equal(t, "identity", NormalizeRetryServiceName("Identity"))
+}
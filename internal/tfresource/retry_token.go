@@ -0,0 +1,140 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package tfresource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	oci_common "github.com/oracle/oci-go-sdk/v65/common"
+
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+	"github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+var (
+	// number of creates seen so far for the same resource type and configuration, see newCreateRetryToken
+	createRetryTokenOccurrences      = map[string]int{}
+	createRetryTokenOccurrencesMutex sync.Mutex
+)
+
+type rawConfigResourceData interface {
+	GetRawConfig() cty.Value
+}
+
+// createRetryTokenInterceptor replaces the retry token of the create request with the deterministic token of the resource.
+// Only the first request that carries a retry token is the create, later requests made by the same Create keep their own token.
+type createRetryTokenInterceptor struct {
+	token         string
+	mutex         sync.Mutex
+	originalToken string
+}
+
+func (i *createRetryTokenInterceptor) intercept(request *http.Request) {
+	currentToken := request.Header.Get(globalvar.RequestHeaderOpcRetryToken)
+	if currentToken == "" {
+		return
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.originalToken == "" {
+		i.originalToken = currentToken
+	}
+	// the SDK sends the same token again when it retries the request
+	if currentToken == i.originalToken {
+		request.Header.Set(globalvar.RequestHeaderOpcRetryToken, i.token)
+	}
+}
+
+func (i *createRetryTokenInterceptor) wrap(interceptor oci_common.RequestInterceptor) oci_common.RequestInterceptor {
+	return func(request *http.Request) error {
+		if interceptor != nil {
+			if err := interceptor(request); err != nil {
+				return err
+			}
+		}
+		i.intercept(request)
+		return nil
+	}
+}
+
+// newCreateRetryToken derives the retry token of a create from the resource type and its configuration, so that a create
+// retried after a timeout or an interrupted apply is deduplicated by the service. Terraform does not send the resource
+// address to the provider, resources of the same type with the same configuration are told apart by the order of their creates.
+func newCreateRetryToken(d schemaResourceData, sync interface{}) string {
+	if deterministicRetryToken, _ := strconv.ParseBool(utils.GetEnvSettingWithDefault(globalvar.DeterministicRetryTokenEnv, "true")); !deterministicRetryToken {
+		return ""
+	}
+	rawConfigData, ok := d.(rawConfigResourceData)
+	if !ok {
+		return ""
+	}
+	rawConfig := rawConfigData.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsWhollyKnown() {
+		return ""
+	}
+	config, err := ctyjson.Marshal(rawConfig, rawConfig.Type())
+	if err != nil {
+		log.Printf("[DEBUG] unable to derive the retry token of the create: %v", err)
+		return ""
+	}
+
+	configHash := sha256.Sum256(append([]byte(reflect.TypeOf(sync).String()+"\n"), config...))
+	configKey := hex.EncodeToString(configHash[:])
+
+	createRetryTokenOccurrencesMutex.Lock()
+	occurrence := createRetryTokenOccurrences[configKey]
+	createRetryTokenOccurrences[configKey]++
+	createRetryTokenOccurrencesMutex.Unlock()
+
+	token := sha256.Sum256([]byte(fmt.Sprintf("%s-%d", configKey, occurrence)))
+	return hex.EncodeToString(token[:])
+}
+
+// withCreateRetryToken runs create with copies of the clients of the resource that send the deterministic retry token of the create
+func withCreateRetryToken(d schemaResourceData, sync interface{}, create func() error) error {
+	token := newCreateRetryToken(d, sync)
+	if token == "" {
+		return create()
+	}
+
+	crud := reflect.ValueOf(sync)
+	if crud.Kind() != reflect.Ptr || crud.IsNil() || crud.Elem().Kind() != reflect.Struct {
+		return create()
+	}
+	crud = crud.Elem()
+
+	interceptor := &createRetryTokenInterceptor{token: token}
+	baseClientType := reflect.TypeOf(oci_common.BaseClient{})
+	for i := 0; i < crud.NumField(); i++ {
+		field := crud.Field(i)
+		if !field.CanSet() || field.Kind() != reflect.Ptr || field.IsNil() || field.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		baseClient := field.Elem().FieldByName("BaseClient")
+		if !baseClient.IsValid() || baseClient.Type() != baseClientType {
+			continue
+		}
+
+		client := reflect.New(field.Elem().Type())
+		client.Elem().Set(field.Elem())
+		clientBaseClient := client.Elem().FieldByName("BaseClient").Addr().Interface().(*oci_common.BaseClient)
+		clientBaseClient.Interceptor = interceptor.wrap(clientBaseClient.Interceptor)
+
+		originalClient := reflect.ValueOf(field.Interface())
+		field.Set(client)
+		defer field.Set(originalClient)
+	}
+
+	return create()
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package tfresource
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	oci_common "github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oracle/terraform-provider-oci/internal/globalvar"
+)
+
+type mockRawConfigResourceData struct {
+	mockResourceData
+	rawConfig cty.Value
+}
+
+func (d *mockRawConfigResourceData) GetRawConfig() cty.Value {
+	return d.rawConfig
+}
+
+type mockRetryTokenClient struct {
+	oci_common.BaseClient
+}
+
+type retryTokenResourceCrud struct {
+	BaseCrud
+	Client *mockRetryTokenClient
+}
+
+func newRetryTokenResourceData(displayName cty.Value) *mockRawConfigResourceData {
+	return &mockRawConfigResourceData{rawConfig: cty.ObjectVal(map[string]cty.Value{"display_name": displayName})}
+}
+
+func TestUnitNewCreateRetryToken(t *testing.T) {
+	sync := &retryTokenResourceCrud{}
+
+	first := newCreateRetryToken(newRetryTokenResourceData(cty.StringVal("a")), sync)
+	assert.Len(t, first, 64)
+	// the same resource type and configuration created again in the same run gets its own token
+	second := newCreateRetryToken(newRetryTokenResourceData(cty.StringVal("a")), sync)
+	assert.Len(t, second, 64)
+	assert.NotEqual(t, first, second)
+	assert.NotEqual(t, first, newCreateRetryToken(newRetryTokenResourceData(cty.StringVal("b")), sync))
+	assert.NotEqual(t, first, newCreateRetryToken(newRetryTokenResourceData(cty.StringVal("a")), &pendingCreateResourceCrud{}))
+
+	assert.Empty(t, newCreateRetryToken(newRetryTokenResourceData(cty.UnknownVal(cty.String)), sync))
+	assert.Empty(t, newCreateRetryToken(&mockResourceData{}, sync))
+	assert.Empty(t, newCreateRetryToken(nil, sync))
+
+	t.Setenv(globalvar.TfEnvPrefix+globalvar.DeterministicRetryTokenEnv, "false")
+	assert.Empty(t, newCreateRetryToken(newRetryTokenResourceData(cty.StringVal("a")), sync))
+}
+
+func TestUnitCreateRetryTokenInterceptor(t *testing.T) {
+	interceptor := &createRetryTokenInterceptor{token: "deterministic"}
+	newRequest := func(token string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://localhost", nil)
+		if token != "" {
+			request.Header.Set(globalvar.RequestHeaderOpcRetryToken, token)
+		}
+		return request
+	}
+
+	request := newRequest("")
+	interceptor.intercept(request)
+	assert.Empty(t, request.Header.Get(globalvar.RequestHeaderOpcRetryToken))
+
+	request = newRequest("random1")
+	interceptor.intercept(request)
+	assert.Equal(t, "deterministic", request.Header.Get(globalvar.RequestHeaderOpcRetryToken))
+
+	// retry of the create
+	request = newRequest("random1")
+	interceptor.intercept(request)
+	assert.Equal(t, "deterministic", request.Header.Get(globalvar.RequestHeaderOpcRetryToken))
+
+	// another request made by the same create
+	request = newRequest("random2")
+	interceptor.intercept(request)
+	assert.Equal(t, "random2", request.Header.Get(globalvar.RequestHeaderOpcRetryToken))
+}
+
+func TestUnitWithCreateRetryToken(t *testing.T) {
+	client := &mockRetryTokenClient{}
+	sync := &retryTokenResourceCrud{Client: client}
+
+	var token string
+	err := withCreateRetryToken(newRetryTokenResourceData(cty.StringVal("a")), sync, func() error {
+		assert.False(t, sync.Client == client)
+		if assert.NotNil(t, sync.Client.Interceptor) {
+			request, _ := http.NewRequest(http.MethodPost, "https://localhost", nil)
+			request.Header.Set(globalvar.RequestHeaderOpcRetryToken, "random")
+			assert.NoError(t, sync.Client.Interceptor(request))
+			token = request.Header.Get(globalvar.RequestHeaderOpcRetryToken)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, token, 64)
+	assert.True(t, sync.Client == client)
+	assert.Nil(t, client.Interceptor)
+
+	err = withCreateRetryToken(&mockResourceData{}, sync, func() error {
+		assert.True(t, sync.Client == client)
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -4,14 +4,29 @@
 package tfresource
 
 import (
+	"context"
 	"fmt"
+	"path"
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// Tag keys, or glob patterns such as "Operations.*", that are ignored when planning changes to tags
 var DefinedTagsToSuppress []string
+var FreeformTagsToSuppress []string
+
+// Provider level tags that are merged into every resource exposing freeform_tags/defined_tags
+var DefaultFreeformTags map[string]interface{}
+var DefaultDefinedTags map[string]interface{}
+
+const (
+	freeformTagsAttrName = "freeform_tags"
+	definedTagsAttrName  = "defined_tags"
+)
 
 func DefinedTagsToMap(definedTags map[string]map[string]interface{}) map[string]interface{} {
 	var tags = make(map[string]interface{})
@@ -45,10 +60,13 @@ func MapToDefinedTags(rawMap map[string]interface{}) (map[string]map[string]inte
 
 func DefinedTagsDiffSuppressFunction(key string, old string, new string, d *schema.ResourceData) bool {
 	keyParts := strings.Split(key, ".")
-	for _, tags := range DefinedTagsToSuppress {
-		if strings.EqualFold(strings.Join(keyParts[1:], "."), tags) {
-			return true
-		}
+	tagKey := tagKeyFromAttributeKey(key, definedTagsAttrName)
+	if IsTagIgnored(DefinedTagsToSuppress, tagKey) {
+		return true
+	}
+	// A default tag that is already on the remote object should not show up as a removal
+	if new == "" && isDefaultTag(DefaultDefinedTags, tagKey, old) {
+		return true
 	}
 	if old != "" && new != "" {
 		return false
@@ -85,6 +103,174 @@ func DefinedTagsDiffSuppressFunction(key string, old string, new string, d *sche
 	return false
 }
 
+// MergeDefaultTags overlays the resource specific tags on top of the provider level default tags.
+// Keys are compared case-insensitively, and a resource specific value always wins over a default one.
+func MergeDefaultTags(defaultTags map[string]interface{}, resourceTags map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaultTags)+len(resourceTags))
+	for key, value := range defaultTags {
+		merged[key] = value
+	}
+	for key, value := range resourceTags {
+		for defaultKey := range defaultTags {
+			if defaultKey != key && strings.EqualFold(defaultKey, key) {
+				delete(merged, defaultKey)
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// AddDefaultTagsCustomizeDiff makes the resource plan the provider level default tags along with its own
+// top level freeform_tags and defined_tags. Only Optional+Computed tag attributes are considered since
+// the planned value can't be set otherwise.
+func AddDefaultTagsCustomizeDiff(resource *schema.Resource) {
+	if resource == nil {
+		return
+	}
+	var tagAttrs []string
+	for _, attr := range []string{freeformTagsAttrName, definedTagsAttrName} {
+		if s, ok := resource.Schema[attr]; ok && s.Type == schema.TypeMap && s.Optional && s.Computed {
+			tagAttrs = append(tagAttrs, attr)
+		}
+	}
+	if len(tagAttrs) == 0 {
+		return
+	}
+
+	if resource.CustomizeDiff == nil {
+		resource.CustomizeDiff = defaultTagsCustomizeDiff(tagAttrs)
+	} else {
+		resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff, defaultTagsCustomizeDiff(tagAttrs))
+	}
+}
+
+func defaultTagsCustomizeDiff(tagAttrs []string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		for _, attr := range tagAttrs {
+			defaultTags, ignoredTags := DefaultFreeformTags, FreeformTagsToSuppress
+			if attr == definedTagsAttrName {
+				defaultTags, ignoredTags = DefaultDefinedTags, DefinedTagsToSuppress
+			}
+			if len(defaultTags) == 0 || !d.NewValueKnown(attr) {
+				continue
+			}
+
+			planned, _ := d.Get(attr).(map[string]interface{})
+			resourceTags, configured := configuredTags(d.GetRawConfig(), attr)
+			if !configured {
+				// Nothing in the config, the planned value is the refreshed state which already has the
+				// previous defaults. Drop those so that changes to the defaults get picked up.
+				resourceTags = make(map[string]interface{}, len(planned))
+				for key, value := range planned {
+					if !hasTagKey(defaultTags, key) {
+						resourceTags[key] = value
+					}
+				}
+			}
+
+			merged := MergeDefaultTags(defaultTags, resourceTags)
+			// Ignored tags that were added outside of Terraform are kept as they are
+			prior, _ := d.GetChange(attr)
+			if priorTags, ok := prior.(map[string]interface{}); ok {
+				for key, value := range priorTags {
+					if IsTagIgnored(ignoredTags, key) && !hasTagKey(merged, key) {
+						merged[key] = value
+					}
+				}
+			}
+			if reflect.DeepEqual(ToLowerCaseKeyMap(planned), ToLowerCaseKeyMap(merged)) {
+				continue
+			}
+			if err := d.SetNew(attr, merged); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// configuredTags returns the tags for the attribute as written in the resource config, and whether they were set at all
+func configuredTags(rawConfig cty.Value, attr string) (map[string]interface{}, bool) {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().IsObjectType() || !rawConfig.Type().HasAttribute(attr) {
+		return nil, false
+	}
+	value := rawConfig.GetAttr(attr)
+	if value.IsNull() || !value.IsKnown() {
+		return nil, false
+	}
+
+	tags := make(map[string]interface{})
+	for key, element := range value.AsValueMap() {
+		if element.IsNull() || !element.IsKnown() || !element.Type().Equals(cty.String) {
+			continue
+		}
+		tags[key] = element.AsString()
+	}
+	return tags, true
+}
+
+func hasTagKey(tags map[string]interface{}, key string) bool {
+	for tagKey := range tags {
+		if strings.EqualFold(tagKey, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDefaultTag(defaultTags map[string]interface{}, key string, value string) bool {
+	for tagKey, tagValue := range defaultTags {
+		if strings.EqualFold(tagKey, key) && fmt.Sprintf("%v", tagValue) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func FreeformTagsDiffSuppressFunction(key string, old string, new string, d *schema.ResourceData) bool {
+	return IsTagIgnored(FreeformTagsToSuppress, tagKeyFromAttributeKey(key, freeformTagsAttrName))
+}
+
+// IsTagIgnored checks the tag key against a list of exact keys and glob patterns, ignoring case
+func IsTagIgnored(ignoredTags []string, tagKey string) bool {
+	for _, ignoredTag := range ignoredTags {
+		if strings.EqualFold(ignoredTag, tagKey) {
+			return true
+		}
+		if matched, err := path.Match(strings.ToLower(ignoredTag), strings.ToLower(tagKey)); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// tagKeyFromAttributeKey returns the tag key part of a flatmap key
+// For example: "create_vnic_details.0.defined_tags.mynamespace.mykey" => "mynamespace.mykey"
+func tagKeyFromAttributeKey(key string, tagsAttrName string) string {
+	keyParts := strings.Split(key, ".")
+	for i, keyPart := range keyParts {
+		if strings.EqualFold(keyPart, tagsAttrName) {
+			return strings.Join(keyParts[i+1:], ".")
+		}
+	}
+	return strings.Join(keyParts[1:], ".")
+}
+
+// AddTagsDiffSuppress makes sure ignored tags are suppressed for the top level tag attributes of the resource,
+// for those that don't already do their own diff suppression.
+func AddTagsDiffSuppress(resource *schema.Resource) {
+	if resource == nil {
+		return
+	}
+	if s, ok := resource.Schema[freeformTagsAttrName]; ok && s.Type == schema.TypeMap && s.Optional && s.DiffSuppressFunc == nil {
+		s.DiffSuppressFunc = FreeformTagsDiffSuppressFunction
+	}
+	if s, ok := resource.Schema[definedTagsAttrName]; ok && s.Type == schema.TypeMap && s.Optional && s.DiffSuppressFunc == nil {
+		s.DiffSuppressFunc = DefinedTagsDiffSuppressFunction
+	}
+}
+
 func ToLowerCaseKeyMap(original map[string]interface{}) map[string]interface{} {
 	lowercaseKeyMap := make(map[string]interface{}, len(original))
 	for key, value := range original {
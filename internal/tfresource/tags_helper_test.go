@@ -268,3 +268,123 @@ func TestUnitDefinedTagsDiffSuppressFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitDefinedTagsDiffSuppressFunction_defaultTags(t *testing.T) {
+	DefaultDefinedTags = map[string]interface{}{"CostCenter.Owner": "team-a"}
+	defer func() { DefaultDefinedTags = nil }()
+
+	if !DefinedTagsDiffSuppressFunction("defined_tags.costcenter.owner", "team-a", "", nil) {
+		t.Errorf("DefinedTagsDiffSuppressFunction() should suppress a default tag already on the resource")
+	}
+	if DefinedTagsDiffSuppressFunction("defined_tags.costcenter.owner", "team-b", "team-a", nil) {
+		t.Errorf("DefinedTagsDiffSuppressFunction() should not suppress a change to a default tag")
+	}
+}
+
+func TestUnitMergeDefaultTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultTags  map[string]interface{}
+		resourceTags map[string]interface{}
+		want         map[string]interface{}
+	}{
+		{
+			name:         "Test defaults only",
+			defaultTags:  map[string]interface{}{"owner": "team-a"},
+			resourceTags: nil,
+			want:         map[string]interface{}{"owner": "team-a"},
+		},
+		{
+			name:         "Test resource tags are added to defaults",
+			defaultTags:  map[string]interface{}{"owner": "team-a"},
+			resourceTags: map[string]interface{}{"app": "web"},
+			want:         map[string]interface{}{"owner": "team-a", "app": "web"},
+		},
+		{
+			name:         "Test resource tags take precedence regardless of case",
+			defaultTags:  map[string]interface{}{"Ops.Owner": "team-a"},
+			resourceTags: map[string]interface{}{"ops.owner": "team-b"},
+			want:         map[string]interface{}{"ops.owner": "team-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeDefaultTags(tt.defaultTags, tt.resourceTags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeDefaultTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitAddDefaultTagsCustomizeDiff(t *testing.T) {
+	tagSchema := func(computed bool) *schema.Schema {
+		return &schema.Schema{Type: schema.TypeMap, Optional: true, Computed: computed, Elem: &schema.Schema{Type: schema.TypeString}}
+	}
+	tests := []struct {
+		name     string
+		resource *schema.Resource
+		want     bool
+	}{
+		{
+			name:     "Test resource with tags",
+			resource: &schema.Resource{Schema: map[string]*schema.Schema{"freeform_tags": tagSchema(true), "defined_tags": tagSchema(true)}},
+			want:     true,
+		},
+		{
+			name:     "Test resource with tags that are not computed",
+			resource: &schema.Resource{Schema: map[string]*schema.Schema{"freeform_tags": tagSchema(false)}},
+			want:     false,
+		},
+		{
+			name:     "Test resource without tags",
+			resource: &schema.Resource{Schema: map[string]*schema.Schema{"display_name": {Type: schema.TypeString, Optional: true}}},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			AddDefaultTagsCustomizeDiff(tt.resource)
+			if got := tt.resource.CustomizeDiff != nil; got != tt.want {
+				t.Errorf("AddDefaultTagsCustomizeDiff() set CustomizeDiff = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitIsTagIgnored(t *testing.T) {
+	ignoredTags := []string{"Oracle-Tags.CreatedBy", "Operations.*", "ops-*"}
+	tests := []struct {
+		name   string
+		tagKey string
+		want   bool
+	}{
+		{name: "Test exact key", tagKey: "Oracle-Tags.CreatedBy", want: true},
+		{name: "Test exact key with different case", tagKey: "oracle-tags.createdby", want: true},
+		{name: "Test namespace pattern", tagKey: "Operations.CostCenter", want: true},
+		{name: "Test prefix pattern", tagKey: "ops-owner", want: true},
+		{name: "Test key that is not ignored", tagKey: "Oracle-Tags.CreatedOn", want: false},
+		{name: "Test prefix pattern does not match the middle of a key", tagKey: "devops-owner", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTagIgnored(ignoredTags, tt.tagKey); got != tt.want {
+				t.Errorf("IsTagIgnored() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitFreeformTagsDiffSuppressFunction(t *testing.T) {
+	FreeformTagsToSuppress = []string{"ops-*"}
+	defer func() { FreeformTagsToSuppress = nil }()
+
+	if !FreeformTagsDiffSuppressFunction("freeform_tags.ops-owner", "team-a", "", nil) {
+		t.Errorf("FreeformTagsDiffSuppressFunction() should suppress an ignored tag")
+	}
+	if !FreeformTagsDiffSuppressFunction("create_vnic_details.0.freeform_tags.ops-owner", "team-a", "", nil) {
+		t.Errorf("FreeformTagsDiffSuppressFunction() should suppress an ignored tag in a nested block")
+	}
+	if FreeformTagsDiffSuppressFunction("freeform_tags.owner", "team-a", "", nil) {
+		t.Errorf("FreeformTagsDiffSuppressFunction() should not suppress a tag that is not ignored")
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package tfresource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	oci_work_requests "github.com/oracle/oci-go-sdk/v65/workrequests"
+
+	"github.com/oracle/terraform-provider-oci/internal/utils"
+)
+
+// number of the most recent work request log entries kept for the error of a failed work request
+const workRequestLogLinesInError = 5
+
+type workReqLogsClient interface {
+	ListWorkRequestLogs(context.Context, oci_work_requests.ListWorkRequestLogsRequest) (oci_work_requests.ListWorkRequestLogsResponse, error)
+}
+
+// WorkRequestFailedError is returned when a work request did not succeed, it carries the work request ID and its last log entries
+type WorkRequestFailedError struct {
+	WorkRequestId string
+	LogLines      []string
+	Message       string
+}
+
+func (e *WorkRequestFailedError) Error() string {
+	return e.Message
+}
+
+// workRequestProgress reports the progress of a work request while it is polled
+type workRequestProgress struct {
+	workRequestClient workReqClient
+	workRequestId     string
+	percentComplete   float32
+	operationType     string
+	status            string
+	logsSeen          int
+}
+
+func newWorkRequestProgress(workRequestClient workReqClient, workRequestId string) *workRequestProgress {
+	return &workRequestProgress{workRequestClient: workRequestClient, workRequestId: workRequestId, percentComplete: -1}
+}
+
+// newWorkRequestsProgress returns the progress reporters of a comma separated list of work request IDs
+func newWorkRequestsProgress(workRequestClient workReqClient, workRequestIds *string) []*workRequestProgress {
+	if workRequestIds == nil || isNilWorkRequestClient(workRequestClient) {
+		return nil
+	}
+	var progress []*workRequestProgress
+	for _, workRequestId := range strings.Split(*workRequestIds, ",") {
+		if workRequestId = strings.TrimSpace(workRequestId); workRequestId != "" {
+			progress = append(progress, newWorkRequestProgress(workRequestClient, workRequestId))
+		}
+	}
+	return progress
+}
+
+// poll gets the work request and reports its progress
+func (p *workRequestProgress) poll() {
+	response, err := p.workRequestClient.GetWorkRequest(context.Background(), oci_work_requests.GetWorkRequestRequest{
+		WorkRequestId: &p.workRequestId,
+	})
+	if err != nil {
+		log.Printf("[DEBUG] unable to get the progress of work request %s: %v", p.workRequestId, err)
+		return
+	}
+	p.report(&response.WorkRequest)
+}
+
+// report logs the completion, operation and new log entries of the work request when they change
+func (p *workRequestProgress) report(workRequest *oci_work_requests.WorkRequest) {
+	if workRequest == nil {
+		return
+	}
+	var percentComplete float32
+	if workRequest.PercentComplete != nil {
+		percentComplete = *workRequest.PercentComplete
+	}
+	operationType := ""
+	if workRequest.OperationType != nil {
+		operationType = *workRequest.OperationType
+	}
+	status := string(workRequest.Status)
+
+	if percentComplete != p.percentComplete || operationType != p.operationType || status != p.status {
+		p.percentComplete, p.operationType, p.status = percentComplete, operationType, status
+		logWorkRequestProgress(fmt.Sprintf("Work request %s: %s is %s, %.0f%% complete", p.workRequestId, operationType, status, percentComplete),
+			map[string]interface{}{
+				"work_request_id":  p.workRequestId,
+				"operation_type":   operationType,
+				"status":           status,
+				"percent_complete": percentComplete,
+			})
+	}
+
+	logEntries := listWorkRequestLogs(p.workRequestClient, p.workRequestId)
+	if len(logEntries) <= p.logsSeen {
+		return
+	}
+	for _, logEntry := range logEntries[p.logsSeen:] {
+		logWorkRequestProgress(fmt.Sprintf("Work request %s: %s", p.workRequestId, logEntry),
+			map[string]interface{}{
+				"work_request_id": p.workRequestId,
+			})
+	}
+	p.logsSeen = len(logEntries)
+}
+
+func logWorkRequestProgress(message string, fields map[string]interface{}) {
+	utils.Logf("%s\n", message)
+	tflog.Info(context.Background(), message, fields)
+}
+
+// listWorkRequestLogs returns the log entries of the work request, oldest first, if the client is able to list them
+func listWorkRequestLogs(workRequestClient workReqClient, workRequestId string) []string {
+	logsClient, ok := workRequestClient.(workReqLogsClient)
+	if !ok || isNilWorkRequestClient(workRequestClient) {
+		return nil
+	}
+
+	var logEntries []string
+	request := oci_work_requests.ListWorkRequestLogsRequest{
+		WorkRequestId: &workRequestId,
+		SortOrder:     oci_work_requests.ListWorkRequestLogsSortOrderAsc,
+	}
+	for {
+		response, err := logsClient.ListWorkRequestLogs(context.Background(), request)
+		if err != nil {
+			log.Printf("[DEBUG] unable to list the logs of work request %s: %v", workRequestId, err)
+			return logEntries
+		}
+		for _, logEntry := range response.Items {
+			if logEntry.Message == nil {
+				continue
+			}
+			if logEntry.Timestamp != nil {
+				logEntries = append(logEntries, fmt.Sprintf("%s %s", logEntry.Timestamp.Format(time.RFC3339), *logEntry.Message))
+			} else {
+				logEntries = append(logEntries, *logEntry.Message)
+			}
+		}
+		if response.OpcNextPage == nil || *response.OpcNextPage == "" {
+			return logEntries
+		}
+		request.Page = response.OpcNextPage
+	}
+}
+
+// lastWorkRequestLogLines returns the most recent log entries of the work request
+func lastWorkRequestLogLines(workRequestClient workReqClient, workRequestId string) []string {
+	logEntries := listWorkRequestLogs(workRequestClient, workRequestId)
+	if len(logEntries) > workRequestLogLinesInError {
+		logEntries = logEntries[len(logEntries)-workRequestLogLinesInError:]
+	}
+	return logEntries
+}
+
+func isNilWorkRequestClient(workRequestClient workReqClient) bool {
+	if workRequestClient == nil {
+		return true
+	}
+	value := reflect.ValueOf(workRequestClient)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
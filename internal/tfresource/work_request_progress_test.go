@@ -0,0 +1,106 @@
+// Copyright (c) 2017, 2024, Oracle and/or its affiliates. All rights reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package tfresource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	oci_common "github.com/oracle/oci-go-sdk/v65/common"
+	oci_work_requests "github.com/oracle/oci-go-sdk/v65/workrequests"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWorkRequestLogsClient struct {
+	mockWorkRequestClient
+	logs      []string
+	pageSize  int
+	listCalls int
+}
+
+func (client *mockWorkRequestLogsClient) ListWorkRequestLogs(_ context.Context, request oci_work_requests.ListWorkRequestLogsRequest) (oci_work_requests.ListWorkRequestLogsResponse, error) {
+	client.listCalls++
+	start := 0
+	if request.Page != nil {
+		fmt.Sscanf(*request.Page, "%d", &start)
+	}
+	end := start + client.pageSize
+	if end > len(client.logs) {
+		end = len(client.logs)
+	}
+
+	response := oci_work_requests.ListWorkRequestLogsResponse{}
+	for i := start; i < end; i++ {
+		message := client.logs[i]
+		response.Items = append(response.Items, oci_work_requests.WorkRequestLogEntry{Message: &message})
+	}
+	if end < len(client.logs) {
+		nextPage := fmt.Sprintf("%d", end)
+		response.OpcNextPage = &nextPage
+	}
+	return response, nil
+}
+
+func TestUnitListWorkRequestLogs(t *testing.T) {
+	client := &mockWorkRequestLogsClient{logs: []string{"a", "b", "c", "d", "e", "f", "g"}, pageSize: 3}
+
+	assert.Equal(t, client.logs, listWorkRequestLogs(client, "wr"))
+	assert.Equal(t, 3, client.listCalls)
+	assert.Equal(t, []string{"c", "d", "e", "f", "g"}, lastWorkRequestLogLines(client, "wr"))
+
+	assert.Nil(t, listWorkRequestLogs(&mockWorkRequestClient{}, "wr"))
+	var nilClient *mockWorkRequestLogsClient
+	assert.Nil(t, listWorkRequestLogs(nilClient, "wr"))
+}
+
+func TestUnitWorkRequestProgressReport(t *testing.T) {
+	client := &mockWorkRequestLogsClient{logs: []string{"Accepted"}, pageSize: 10}
+	progress := newWorkRequestProgress(client, "wr")
+
+	operationType := "CREATE_VM_CLUSTER"
+	percentComplete := float32(0)
+	workRequest := &oci_work_requests.WorkRequest{OperationType: &operationType, Status: oci_work_requests.WorkRequestStatusAccepted, PercentComplete: &percentComplete}
+
+	progress.report(workRequest)
+	assert.Equal(t, oci_work_requests.WorkRequestStatusAccepted, oci_work_requests.WorkRequestStatusEnum(progress.status))
+	assert.Equal(t, 1, progress.logsSeen)
+
+	percentComplete = 42
+	workRequest.Status = oci_work_requests.WorkRequestStatusInProgress
+	client.logs = append(client.logs, "Provisioning the VM cluster")
+	progress.report(workRequest)
+	assert.Equal(t, float32(42), progress.percentComplete)
+	assert.Equal(t, "CREATE_VM_CLUSTER", progress.operationType)
+	assert.Equal(t, 2, progress.logsSeen)
+
+	progress.report(nil)
+	assert.Equal(t, 2, progress.logsSeen)
+}
+
+func TestUnitNewWorkRequestsProgress(t *testing.T) {
+	workRequestIds := "wr1, wr2,"
+	progress := newWorkRequestsProgress(&mockWorkRequestClient{}, &workRequestIds)
+	if assert.Len(t, progress, 2) {
+		assert.Equal(t, "wr1", progress[0].workRequestId)
+		assert.Equal(t, "wr2", progress[1].workRequestId)
+	}
+
+	var nilClient *mockWorkRequestClient
+	assert.Nil(t, newWorkRequestsProgress(nilClient, &workRequestIds))
+	assert.Nil(t, newWorkRequestsProgress(&mockWorkRequestClient{}, nil))
+}
+
+func TestUnitGetWorkRequestErrorsWithLogs(t *testing.T) {
+	client := &mockWorkRequestLogsClient{logs: []string{"a", "b", "c", "d", "e", "f"}, pageSize: 10}
+	workRequestId := "wr"
+
+	err := getWorkRequestErrors(client, &workRequestId, &oci_common.RetryPolicy{}, "vmcluster", oci_work_requests.WorkRequestResourceActionTypeCreated)
+	workRequestErr, ok := err.(*WorkRequestFailedError)
+	if assert.True(t, ok) {
+		assert.Equal(t, "wr", workRequestErr.WorkRequestId)
+		assert.Equal(t, []string{"b", "c", "d", "e", "f"}, workRequestErr.LogLines)
+		assert.Contains(t, workRequestErr.Error(), "work request did not succeed, workId: wr")
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	tf_export "github.com/oracle/terraform-provider-oci/internal/commonexport"
 	"github.com/oracle/terraform-provider-oci/internal/globalvar"
@@ -23,11 +23,10 @@ var filterFlag tf_export.Filter
 func init() {
 	// Tie the command-line flag to the intervalFlag variable and
 	// set a usage message.
-	flag.Var(&filterFlag, "filter", "pass a filter to filter resources discovered. Use the flag multiple times to pass multiple filters")
+	flag.Var(&filterFlag, "filter", "pass a filter to filter resources discovered. Use the flag multiple times to pass multiple filters. Filters can be combined with AND, OR, NOT and parentheses")
 }
 
 func main() {
-	// TODO: input for resource discovery from a config file
 	var command = flag.String("command", "", "Command to run. Supported commands include: 'export', 'list_export_resources' and 'list_export_services'. 'list_export_services' supports json format.")
 	var listExportServicesPath = flag.String("list_export_services_path", "", "[export] Path to output list of supported services in json format")
 	var compartmentId = flag.String("compartment_id", "", "[export] OCID of a compartment to export. If no compartment id nor name is specified, the root compartment will be used.")
@@ -38,6 +37,14 @@ func main() {
 	var excludeServices = flag.String("exclude_services", "", "[export] [experimental] Comma-separated list of service resources to exclude from export. If a service is present in both 'services' and 'exclude_services' argument, it will be excluded.")
 	var ids = flag.String("ids", "", "[export] Comma-separated list of tuples <resource Type:resource ID> for resources to export. The ID could either be an OCID or a Terraform import ID. By default, all resources are exported.")
 	var generateStateFile = flag.Bool("generate_state", false, "[export][experimental] Set this to import the discovered resources into a state file along with the Terraform configuration")
+	var generateImportBlocks = flag.Bool("generate_import_blocks", false, "[export][experimental] Set this to write Terraform v1.5+ import blocks for the discovered resources along with the Terraform configuration instead of generating a state file. A Terraform CLI is not required")
+	var outputLayout = flag.String("output_layout", tf_export.OutputLayoutFlat, "[export][experimental] Layout of the generated configuration. The allowed values are :\n * flat - one file per service under output_path\n * modules - one module per compartment under output_path/modules, mirroring the compartment tree")
+	var sensitiveAttributes = flag.String("sensitive_attributes", tf_export.SensitiveAttributesVariable, "[export] How to export the attributes that the schema marks as sensitive e.g. passwords and private keys. The allowed values are :\n * variable - export them as sensitive variables without values\n * omit - do not export them")
+	var namingTemplate = flag.String("naming_template", "", "[export] Template of the names of the exported resources e.g. {{abbrev}}_{{display_name}}. The placeholders are resource attributes, abbrev, type, compartment_path, tag.<key> and defined_tag.<namespace>.<key>, alternatives are separated by | e.g. {{tag.Name|display_name}}. Resources without a value for a placeholder keep the default name, duplicate names get a numeric suffix")
+	var baselineState = flag.String("baseline_state", "", "[export][experimental] Path to the state file of a previous export. Only the resources that are not in the previous export are generated, and a drift report of the added, removed and changed resources is written to output_path")
+	var reportPath = flag.String("report_path", "", "[export] Path to write a JSON report of the export with the resources discovered by each step, the time taken and the errors")
+	var exportConfig = flag.String("export_config", "", "[export] Path to a .yaml, .json or .hcl file with the arguments of the export, using the names of the command line arguments as keys. The arguments set on the command line take precedence over the file")
+	var regions = flag.String("regions", "", "[export] Comma-separated list of regions to export in one run. The first region is the region of the default provider, the resources of the other regions use a provider with an alias. By default, the region of the provider configuration is exported")
 	var help = flag.Bool("help", false, "Prints usage options")
 	var tfVersion = flag.String("tf_version", "0.12", "The version of terraform syntax to generate for configurations. The state file will be written in v0.12 only. The allowed values are :\n * 0.11\n * 0.12")
 	var retryTimeout = flag.String("retry_timeout", "15s", "[export] The time duration for which API calls will wait and retry operation in case of API errors. By default, the retry timeout duration is 15s")
@@ -56,21 +63,17 @@ func main() {
 	if command == nil || *command == "" {
 		log.Println("Executable runs in Terraform plugin mode by default. For additional usage options, please run with the '-help' flag.")
 		plugin.Serve(&plugin.ServeOpts{
-			ProviderFunc: func() *schema.Provider {
-				return provider.Provider()
+			GRPCProviderFunc: func() tfprotov5.ProviderServer {
+				return provider.ProviderServer(provider.Provider())
 			},
 		})
 	} else {
 		switch *command {
 		case "export":
 
-			var terraformVersion tf_export.TfHclVersion
-			if tf_export.TfVersionEnum(*tfVersion) == tf_export.TfVersion11 {
-				terraformVersion = &tf_export.TfHclVersion11{Value: tf_export.TfVersionEnum(*tfVersion)}
-			} else if *tfVersion == "" || tf_export.TfVersionEnum(*tfVersion) == tf_export.TfVersion12 {
-				terraformVersion = &tf_export.TfHclVersion12{Value: tf_export.TfVersionEnum(*tfVersion)}
-			} else {
-				color.Red("[ERROR]: Invalid tf_version '%s', supported values: 0.11, 0.12\n", *tfVersion)
+			terraformVersion, err := tf_export.GetTfHclVersion(*tfVersion)
+			if err != nil {
+				color.Red("%v\n", err)
 				os.Exit(1)
 			}
 
@@ -84,6 +87,12 @@ func main() {
 				CompartmentName:              compartmentName,
 				OutputDir:                    outputPath,
 				GenerateState:                *generateStateFile,
+				GenerateImportBlocks:         *generateImportBlocks,
+				OutputLayout:                 *outputLayout,
+				SensitiveAttributes:          *sensitiveAttributes,
+				NamingTemplate:               *namingTemplate,
+				BaselineState:                baselineState,
+				ReportPath:                   reportPath,
 				TFVersion:                    &terraformVersion,
 				RetryTimeout:                 retryTimeout,
 				IsExportWithRelatedResources: *includeRelatedResources,
@@ -110,10 +119,30 @@ func main() {
 				args.IDs = strings.Split(*ids, ",")
 			}
 
+			if regions != nil && *regions != "" {
+				args.Regions = strings.Split(*regions, ",")
+			}
+
 			if filterFlag != nil {
 				args.Filters = filterFlag
 			}
 
+			if exportConfig != nil && *exportConfig != "" {
+				config, err := tf_export.LoadExportConfig(*exportConfig)
+				if err != nil {
+					color.Red("%v", err)
+					os.Exit(1)
+				}
+				overrides := map[string]bool{}
+				flag.Visit(func(f *flag.Flag) {
+					overrides[f.Name] = true
+				})
+				if err := config.ApplyTo(args, overrides); err != nil {
+					color.Red("%v", err)
+					os.Exit(1)
+				}
+			}
+
 			err, status := resourcediscovery.RunExportCommand(args)
 			if err != nil {
 				color.Red("%v", err)